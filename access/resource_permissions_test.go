@@ -388,6 +388,225 @@ func TestResourcePermissionsCreate(t *testing.T) {
 	assert.Equal(t, "CAN_READ", firstElem["permission_level"])
 }
 
+func TestResourcePermissionsCreate_GroupCanManage(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/instance-pools/abc",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							GroupName:       "data-engineers",
+							PermissionLevel: "CAN_MANAGE",
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/instance-pools/abc",
+				Response: ObjectACL{
+					ObjectID:   "/instance-pools/abc",
+					ObjectType: "instance-pool",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "data-engineers",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource: ResourcePermissions(),
+		HCL: `
+		instance_pool_id = "abc"
+
+		access_control {
+			group_name = "data-engineers"
+			permission_level = "CAN_MANAGE"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/instance-pools/abc", d.Id())
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, "data-engineers", firstElem["group_name"])
+	assert.Equal(t, "CAN_MANAGE", firstElem["permission_level"])
+}
+
+func TestResourcePermissionsUpdate_RevokeGroupCanManage(t *testing.T) {
+	prior := ResourcePermissions().TestResourceData()
+	prior.SetId("/clusters/abc")
+	require.NoError(t, prior.Set("cluster_id", "abc"))
+	require.NoError(t, prior.Set("access_control", []interface{}{
+		map[string]interface{}{
+			"group_name":       "data-engineers",
+			"permission_level": "CAN_MANAGE",
+		},
+		map[string]interface{}{
+			"user_name":        TestingUser,
+			"permission_level": "CAN_RESTART",
+		},
+	}))
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/clusters/abc",
+				// the whole desired list is sent, so that the group loses access
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							UserName:        TestingUser,
+							PermissionLevel: "CAN_RESTART",
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/clusters/abc",
+				Response: ObjectACL{
+					ObjectID:   "/clusters/abc",
+					ObjectType: "cluster",
+					AccessControlList: []AccessControl{
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_RESTART",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource:      ResourcePermissions(),
+		InstanceState: prior.State().Attributes,
+		HCL: `
+		cluster_id = "abc"
+
+		access_control {
+			user_name = "ben"
+			permission_level = "CAN_RESTART"
+		}
+		`,
+		Update: true,
+		ID:     "/clusters/abc",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, TestingUser, firstElem["user_name"])
+	assert.Equal(t, "", firstElem["group_name"])
+}
+
+func TestResourcePermissionsCreate_AuthorizationTokens(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/authorization/tokens",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							GroupName:       "data-engineers",
+							PermissionLevel: "CAN_USE",
+						},
+						{
+							GroupName:       "admins",
+							PermissionLevel: "CAN_MANAGE",
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/authorization/tokens",
+				Response: ObjectACL{
+					ObjectID:   "/authorization/tokens",
+					ObjectType: "tokens",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "data-engineers",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_USE",
+									Inherited:       false,
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+									Inherited:       false,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource: ResourcePermissions(),
+		HCL: `
+		authorization = "tokens"
+		access_control {
+			group_name = "data-engineers"
+			permission_level = "CAN_USE"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/authorization/tokens", d.Id())
+	assert.Equal(t, "tokens", d.Get("object_type"))
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, "data-engineers", firstElem["group_name"])
+	assert.Equal(t, "CAN_USE", firstElem["permission_level"])
+}
+
 func TestResourcePermissionsCreate_NotebookPath_NotExists(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
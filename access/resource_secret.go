@@ -2,6 +2,11 @@ package access
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 
@@ -14,6 +19,7 @@ import (
 // SecretsRequest ...
 type SecretsRequest struct {
 	StringValue string `json:"string_value,omitempty" mask:"true"`
+	BytesValue  string `json:"bytes_value,omitempty" mask:"true"`
 	Scope       string `json:"scope,omitempty"`
 	Key         string `json:"key,omitempty"`
 }
@@ -80,6 +86,15 @@ func (a SecretsAPI) Create(stringValue, scope, key string) error {
 	}, nil)
 }
 
+// PutBytes creates or modifies a secret with binary value, that is sent base64-encoded
+func (a SecretsAPI) PutBytes(value []byte, scope, key string) error {
+	return a.client.Post(a.context, "/secrets/put", SecretsRequest{
+		BytesValue: base64.StdEncoding.EncodeToString(value),
+		Scope:      scope,
+		Key:        key,
+	}, nil)
+}
+
 // Delete deletes a secret depends on the type of scope backend
 func (a SecretsAPI) Delete(scope, key string) error {
 	return a.client.Post(a.context, "/secrets/delete", SecretsRequest{
@@ -117,6 +132,39 @@ func (a SecretsAPI) Read(scope string, key string) (SecretMetadata, error) {
 	}
 }
 
+// secretValue returns raw secret value. String value is always used as is, even
+// if it looks like base64, and only byte value is decoded.
+func secretValue(d *schema.ResourceData) ([]byte, error) {
+	if v, ok := d.GetOk("byte_value"); ok {
+		value, err := base64.StdEncoding.DecodeString(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("byte_value is not valid base64: %v", err)
+		}
+		return value, nil
+	}
+	return []byte(d.Get("string_value").(string)), nil
+}
+
+// secretHMACSalt returns random salt for the secret value HMAC, replaced in tests
+var secretHMACSalt = func() ([]byte, error) {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	return salt, err
+}
+
+// saltedSecretHMAC returns `hex(salt)$hex(HMAC-SHA256(salt, value))` of the raw
+// secret value, so that low-entropy secrets cannot be recovered from the state
+// with precomputed hashes. It's not a plain SHA-256 of the value.
+func saltedSecretHMAC(value []byte) (string, error) {
+	salt, err := secretHMACSalt()
+	if err != nil {
+		return "", fmt.Errorf("Cannot generate salt: %w", err)
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(value)
+	return fmt.Sprintf("%s$%x", hex.EncodeToString(salt), mac.Sum(nil)), nil
+}
+
 // ResourceSecret manages secrets
 func ResourceSecret() *schema.Resource {
 	p := common.NewPairSeparatedID("scope", "key", "|||")
@@ -125,9 +173,23 @@ func ResourceSecret() *schema.Resource {
 			"string_value": {
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringIsNotEmpty,
-				Required:     true,
+				Optional:     true,
 				ForceNew:     true,
 				Sensitive:    true,
+				ExactlyOneOf: []string{"string_value", "byte_value"},
+			},
+			"byte_value": {
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsBase64,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"string_value", "byte_value"},
+			},
+			"value_hmac": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"scope": {
 				Type:         schema.TypeString,
@@ -147,12 +209,20 @@ func ResourceSecret() *schema.Resource {
 			},
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			if err := NewSecretsAPI(ctx, c).Create(d.Get("string_value").(string), d.Get("scope").(string),
+			value, err := secretValue(d)
+			if err != nil {
+				return err
+			}
+			if err = NewSecretsAPI(ctx, c).PutBytes(value, d.Get("scope").(string),
 				d.Get("key").(string)); err != nil {
 				return err
 			}
 			p.Pack(d)
-			return nil
+			valueHMAC, err := saltedSecretHMAC(value)
+			if err != nil {
+				return err
+			}
+			return d.Set("value_hmac", valueHMAC)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			scope, key, err := p.Unpack(d)
@@ -1,14 +1,40 @@
 package access
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// withFixedSecretHMACSalt makes secret HMACs deterministic and returns cleanup function
+func withFixedSecretHMACSalt() func() {
+	original := secretHMACSalt
+	secretHMACSalt = func() ([]byte, error) {
+		return []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, nil
+	}
+	return func() {
+		secretHMACSalt = original
+	}
+}
+
+func TestSaltedSecretHMAC_RandomSalt(t *testing.T) {
+	first, err := saltedSecretHMAC([]byte("abc"))
+	require.NoError(t, err)
+	second, err := saltedSecretHMAC([]byte("abc"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, strings.Split(first, "$"), 2)
+}
+
+func TestResourceSecretSchema_HMACIsSensitive(t *testing.T) {
+	assert.True(t, ResourceSecret().Schema["value_hmac"].Sensitive)
+}
+
 func TestResourceSecretRead(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -82,15 +108,16 @@ func TestResourceSecretRead_Error(t *testing.T) {
 }
 
 func TestResourceSecretCreate(t *testing.T) {
+	defer withFixedSecretHMACSalt()()
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/secrets/put",
 				ExpectedRequest: SecretsRequest{
-					StringValue: "SparkIsTh3Be$t",
-					Scope:       "foo",
-					Key:         "bar",
+					BytesValue: "U3BhcmtJc1RoM0JlJHQ=",
+					Scope:      "foo",
+					Key:        "bar",
 				},
 			},
 			{
@@ -116,6 +143,103 @@ func TestResourceSecretCreate(t *testing.T) {
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "foo|||bar", d.Id())
+	assert.Equal(t, "000102030405060708090a0b0c0d0e0f$ce63d970148f32fe2b086b9da8a37c934b401f71a270ec3c741c665431752524",
+		d.Get("value_hmac"))
+}
+
+func TestResourceSecretCreate_StringLooksLikeBase64(t *testing.T) {
+	defer withFixedSecretHMACSalt()()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/put",
+				ExpectedRequest: SecretsRequest{
+					// base64 of "YWJj" itself, not of "abc"
+					BytesValue: "WVdKag==",
+					Scope:      "foo",
+					Key:        "bar",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 12345678,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": "YWJj",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "foo|||bar", d.Id())
+	assert.Equal(t, "000102030405060708090a0b0c0d0e0f$9c3657b13c3c3abdfcadebb6e3934f68cc7965748faad6f6faf9eb4a8d64c385",
+		d.Get("value_hmac"))
+}
+
+func TestResourceSecretCreate_ByteValue(t *testing.T) {
+	defer withFixedSecretHMACSalt()()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/put",
+				ExpectedRequest: SecretsRequest{
+					BytesValue: "YWJj",
+					Scope:      "foo",
+					Key:        "bar",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 12345678,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":      "foo",
+			"key":        "bar",
+			"byte_value": "YWJj",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "foo|||bar", d.Id())
+	// salted HMAC of decoded "abc"
+	assert.Equal(t, "000102030405060708090a0b0c0d0e0f$d601cc177559b0248459787f7e804ed7f27689b5995c59b661802d9682fdf8d2",
+		d.Get("value_hmac"))
+}
+
+func TestResourceSecretCreate_BothValues(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": "abc",
+			"byte_value":   "YWJj",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [byte_value] ExactlyOne. [string_value] ExactlyOne")
 }
 
 func TestResourceSecretCreate_Error(t *testing.T) {
@@ -40,10 +40,14 @@ type AzureAuth struct {
 	PATTokenDurationSeconds string
 	UsePATForCLI            bool
 
+	// use Azure Managed Identity, where ClientID selects user-assigned identity
+	UseMSI bool
+
 	// private property to give resource access
 	databricksClient *DatabricksClient
 
 	azureManagementEndpoint string
+	msiEndpoint             string
 	authorizer              autorest.Authorizer
 	temporaryPat            *TokenResponse
 }
@@ -117,7 +121,7 @@ func (aa *AzureAuth) IsClientSecretSet() bool {
 	return aa.ClientID != "" && aa.ClientSecret != "" && aa.TenantID != ""
 }
 
-func (aa *AzureAuth) configureWithClientSecret() (func(r *http.Request) error, error) {
+func (aa *AzureAuth) configureWithClientSecret(ctx context.Context) (func(r *http.Request) error, error) {
 	if aa.databricksClient != nil && !aa.databricksClient.IsAzure() {
 		return nil, nil
 	}
@@ -190,12 +190,12 @@ func TestAzureAuth_ensureWorkspaceURL(t *testing.T) {
 
 func TestAzureAuth_configureWithClientSecret(t *testing.T) {
 	aa := AzureAuth{}
-	auth, err := aa.configureWithClientSecret()
+	auth, err := aa.configureWithClientSecret(context.Background())
 	assert.Nil(t, auth)
 	assert.NoError(t, err)
 
 	aa.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
-	auth, err = aa.configureWithClientSecret()
+	auth, err = aa.configureWithClientSecret(context.Background())
 	assert.Nil(t, auth)
 	assert.NoError(t, err)
 
@@ -250,7 +250,7 @@ func TestAzureAuth_configureWithClientSecret(t *testing.T) {
 	aa.TenantID = "c"
 	// resource management endpoints end with a trailing slash in url
 	aa.azureManagementEndpoint = fmt.Sprintf("%s/", server.URL)
-	auth, err = aa.configureWithClientSecret()
+	auth, err = aa.configureWithClientSecret(context.Background())
 	assert.NotNil(t, auth)
 	assert.NoError(t, err)
 
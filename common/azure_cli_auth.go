@@ -95,7 +95,7 @@ func (aa *AzureAuth) cliAuthorizer(resource string) (autorest.Authorizer, error)
 	return autorest.NewBearerAuthorizer(&rct), nil
 }
 
-func (aa *AzureAuth) configureWithAzureCLI() (func(r *http.Request) error, error) {
+func (aa *AzureAuth) configureWithAzureCLI(ctx context.Context) (func(r *http.Request) error, error) {
 	if aa.databricksClient == nil {
 		return nil, nil
 	}
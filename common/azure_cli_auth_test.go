@@ -177,7 +177,7 @@ func TestConfigureWithAzureCLI_SP(t *testing.T) {
 		TenantID:     "c",
 		ResourceID:   "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c",
 	}
-	auth, err := aa.configureWithAzureCLI()
+	auth, err := aa.configureWithAzureCLI(context.Background())
 	assert.NoError(t, err)
 	assert.Nil(t, auth)
 }
@@ -195,7 +195,7 @@ func TestConfigureWithAzureCLI(t *testing.T) {
 	client.AzureAuth.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
 	client.AzureAuth.UsePATForCLI = true
 
-	auth, err := client.AzureAuth.configureWithAzureCLI()
+	auth, err := client.AzureAuth.configureWithAzureCLI(context.Background())
 	assert.NoError(t, err)
 
 	err = auth(httptest.NewRequest("GET", "/clusters/list", http.NoBody))
@@ -215,7 +215,7 @@ func TestConfigureWithAzureCLI_Error(t *testing.T) {
 	client.AzureAuth.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
 	client.AzureAuth.UsePATForCLI = true
 
-	auth, err := client.AzureAuth.configureWithAzureCLI()
+	auth, err := client.AzureAuth.configureWithAzureCLI(context.Background())
 	assert.NoError(t, err)
 
 	err = auth(httptest.NewRequest("GET", "/clusters/list", http.NoBody))
@@ -237,7 +237,7 @@ func TestConfigureWithAzureCLI_NotInstalled(t *testing.T) {
 	client.AzureAuth.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
 	client.AzureAuth.UsePATForCLI = true
 
-	_, err := client.AzureAuth.configureWithAzureCLI()
+	_, err := client.AzureAuth.configureWithAzureCLI(context.Background())
 	require.Error(t, err)
 	assert.True(t, strings.HasPrefix(err.Error(), "Most likely Azure CLI is not installed"),
 		"Actual message: %s", err.Error())
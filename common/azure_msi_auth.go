@@ -0,0 +1,184 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// imdsTokenEndpoint is the well-known token endpoint of Azure Instance Metadata Service
+const imdsTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+type refreshableMSIToken struct {
+	resource       string
+	endpoint       string
+	clientID       string
+	httpClient     *http.Client
+	token          *adal.Token
+	lock           *sync.RWMutex
+	refreshMinutes int
+}
+
+// OAuthToken implements adal.OAuthTokenProvider
+func (rmt *refreshableMSIToken) OAuthToken() string {
+	rmt.lock.RLock()
+	defer rmt.lock.RUnlock()
+	if rmt.token == nil {
+		return ""
+	}
+	return rmt.token.OAuthToken()
+}
+
+// EnsureFreshWithContext implements adal.RefresherWithContext
+func (rmt *refreshableMSIToken) EnsureFreshWithContext(ctx context.Context) error {
+	refreshInterval := time.Duration(rmt.refreshMinutes) * time.Minute
+	if rmt.isFresh(refreshInterval) {
+		return nil
+	}
+	rmt.lock.Lock()
+	defer rmt.lock.Unlock()
+	if rmt.token != nil && !rmt.token.WillExpireIn(refreshInterval) {
+		return nil
+	}
+	return rmt.refreshInternal(ctx)
+}
+
+// isFresh tells if token is present and doesn't expire within given interval
+func (rmt *refreshableMSIToken) isFresh(interval time.Duration) bool {
+	rmt.lock.RLock()
+	defer rmt.lock.RUnlock()
+	return rmt.token != nil && !rmt.token.WillExpireIn(interval)
+}
+
+// RefreshWithContext implements adal.RefresherWithContext
+func (rmt *refreshableMSIToken) RefreshWithContext(ctx context.Context) error {
+	rmt.lock.Lock()
+	defer rmt.lock.Unlock()
+	return rmt.refreshInternal(ctx)
+}
+
+// RefreshExchangeWithContext implements adal.RefresherWithContext
+func (rmt *refreshableMSIToken) RefreshExchangeWithContext(ctx context.Context, resource string) error {
+	rmt.lock.Lock()
+	defer rmt.lock.Unlock()
+	return rmt.refreshInternal(ctx)
+}
+
+func (rmt *refreshableMSIToken) refreshInternal(ctx context.Context) error {
+	query := url.Values{}
+	query.Set("api-version", "2018-02-01")
+	query.Set("resource", rmt.resource)
+	if rmt.clientID != "" {
+		// user-assigned identity
+		query.Set("client_id", rmt.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s?%s", rmt.endpoint, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := rmt.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Cannot get managed identity token: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Cannot get managed identity token: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Cannot get managed identity token: %s %s", resp.Status, body)
+	}
+	var token adal.Token
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return fmt.Errorf("Cannot parse managed identity token: %v", err)
+	}
+	log.Printf("[INFO] Refreshed OAuth token for %s from Azure Managed Identity, which expires on %s",
+		rmt.resource, token.Expires())
+	rmt.token = &token
+	return nil
+}
+
+func (aa *AzureAuth) getMSIEndpoint() string {
+	// Used for unit testing purposes
+	if aa.msiEndpoint != "" {
+		return aa.msiEndpoint
+	}
+	return imdsTokenEndpoint
+}
+
+// getMSIHTTPClient returns HTTP client of the configured Databricks client,
+// so that the same transport settings apply to Instance Metadata Service calls
+func (aa *AzureAuth) getMSIHTTPClient() *http.Client {
+	if aa.databricksClient == nil || aa.databricksClient.httpClient == nil ||
+		aa.databricksClient.httpClient.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return aa.databricksClient.httpClient.HTTPClient
+}
+
+// isMSIAvailable returns true if Azure Instance Metadata Service responds at all,
+// as it is not reachable outside of Azure VMs
+func (aa *AzureAuth) isMSIAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aa.getMSIEndpoint(), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := aa.getMSIHTTPClient().Do(req)
+	if err != nil {
+		log.Printf("[DEBUG] Azure Instance Metadata Service is not available: %s", err)
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// msiAuthorizer returns factory of authorizers with AAD token from Azure Instance
+// Metadata Service. Non-empty client ID selects user-assigned identity.
+func (aa *AzureAuth) msiAuthorizer(ctx context.Context) func(resource string) (autorest.Authorizer, error) {
+	return func(resource string) (autorest.Authorizer, error) {
+		rmt := refreshableMSIToken{
+			lock:           &sync.RWMutex{},
+			resource:       resource,
+			endpoint:       aa.getMSIEndpoint(),
+			clientID:       aa.ClientID,
+			httpClient:     aa.getMSIHTTPClient(),
+			refreshMinutes: 6,
+		}
+		err := rmt.refreshInternal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return autorest.NewBearerAuthorizer(&rmt), nil
+	}
+}
+
+func (aa *AzureAuth) configureWithAzureManagedIdentity(ctx context.Context) (func(r *http.Request) error, error) {
+	if aa.databricksClient == nil || !aa.databricksClient.IsAzure() {
+		return nil, nil
+	}
+	if !aa.UseMSI {
+		return nil, nil
+	}
+	if !aa.isMSIAvailable(ctx) {
+		log.Printf("[WARN] Azure Managed Identity is not available on this host, " +
+			"trying other authentication methods")
+		return nil, nil
+	}
+	log.Printf("[INFO] Using Azure Managed Identity authentication")
+	return aa.simpleAADRequestVisitor(ctx, aa.msiAuthorizer(ctx), aa.addSpManagementTokenVisitor)
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureWithAzureManagedIdentity_NotConfigured(t *testing.T) {
+	aa := AzureAuth{}
+	auth, err := aa.configureWithAzureManagedIdentity(context.Background())
+	assert.Nil(t, auth)
+	assert.NoError(t, err)
+
+	aa.databricksClient = &DatabricksClient{}
+	aa.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
+	auth, err = aa.configureWithAzureManagedIdentity(context.Background())
+	assert.Nil(t, auth, "UseMSI is not set")
+	assert.NoError(t, err)
+}
+
+func TestConfigureWithAzureManagedIdentity_NotAvailable(t *testing.T) {
+	defer CleanupEnvironment()()
+	imds := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {}))
+	// closed server emulates host without Instance Metadata Service
+	imds.Close()
+
+	aa := AzureAuth{
+		ResourceID:  "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c",
+		UseMSI:      true,
+		msiEndpoint: imds.URL,
+	}
+	aa.databricksClient = &DatabricksClient{}
+	auth, err := aa.configureWithAzureManagedIdentity(context.Background())
+	assert.Nil(t, auth, "should fall back to other authentication methods")
+	assert.NoError(t, err)
+}
+
+func TestConfigureWithAzureManagedIdentity(t *testing.T) {
+	defer CleanupEnvironment()()
+	imdsCalls := map[string]int{}
+	imds := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "true", req.Header.Get("Metadata"))
+			resource := req.URL.Query().Get("resource")
+			if resource == "" {
+				// availability probe
+				rw.WriteHeader(400)
+				return
+			}
+			imdsCalls[resource]++
+			assert.Equal(t, "abc", req.URL.Query().Get("client_id"))
+			token := "management-token"
+			if resource == AzureDatabricksResourceID {
+				token = fmt.Sprintf("platform-token-%d", imdsCalls[resource])
+			}
+			// token expires within refresh window, so it's refreshed on every use
+			expiresOn := time.Now().Add(time.Minute).Unix()
+			_, err := rw.Write([]byte(fmt.Sprintf(`{
+				"access_token": "%s",
+				"expires_in": "60",
+				"expires_on": "%d",
+				"resource": "%s",
+				"token_type": "Bearer"
+			}`, token, expiresOn, resource)))
+			assert.NoError(t, err)
+		}))
+	defer imds.Close()
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			if req.RequestURI == "/api/2.0/clusters/list-zones" {
+				authorizations = append(authorizations, req.Header.Get("Authorization"))
+				assert.Equal(t, "management-token",
+					req.Header.Get("X-Databricks-Azure-SP-Management-Token"))
+				assert.Equal(t, "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c",
+					req.Header.Get("X-Databricks-Azure-Workspace-Resource-Id"))
+				_, err := rw.Write([]byte(`{"zones": ["a", "b", "c"]}`))
+				assert.NoError(t, err)
+				return
+			}
+			assert.Fail(t, fmt.Sprintf("Received unexpected call: %s %s",
+				req.Method, req.RequestURI))
+		}))
+	defer server.Close()
+
+	client := DatabricksClient{
+		Host: server.URL,
+		AzureAuth: AzureAuth{
+			ResourceID:  "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c",
+			ClientID:    "abc",
+			UseMSI:      true,
+			msiEndpoint: imds.URL,
+		},
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	type ZonesInfo struct {
+		Zones []string `json:"zones,omitempty"`
+	}
+	var zi ZonesInfo
+	err = client.Get(context.Background(), "/clusters/list-zones", nil, &zi)
+	require.NoError(t, err)
+	assert.Len(t, zi.Zones, 3)
+
+	err = client.Get(context.Background(), "/clusters/list-zones", nil, &zi)
+	require.NoError(t, err)
+
+	// first token is acquired during configuration and every request refreshes it
+	assert.Equal(t, []string{"Bearer platform-token-2", "Bearer platform-token-3"},
+		authorizations, "Token has to be refreshed before it expires")
+}
@@ -25,35 +25,60 @@ const (
 	DefaultTruncateBytes      = 96
 	DefaultRateLimitPerSecond = 15
 	DefaultHTTPTimeoutSeconds = 60
+	// DefaultRetryDelay is the delay between retries of transient HTTP errors
+	DefaultRetryDelay = 10 * time.Second
+	// DefaultClusterCreateMaxAttempts is configured through provider
+	DefaultClusterCreateMaxAttempts = 3
 )
 
 // DatabricksClient is the client struct that contains clients for all the services available on Databricks
 type DatabricksClient struct {
-	Host               string
-	Token              string
-	Username           string
-	Password           string
-	Profile            string
-	ConfigFile         string
+	Host       string
+	Token      string
+	Username   string
+	Password   string
+	Profile    string
+	ConfigFile string
+	// AccountID of the E2 account, that puts the client into account-level mode, where
+	// account APIs are called through the accounts host
 	AccountID          string
 	AzureAuth          AzureAuth
 	InsecureSkipVerify bool
 	HTTPTimeoutSeconds int
+	// RetryDelay overrides DefaultRetryDelay, e.g. to speed up tests of retries
+	RetryDelay time.Duration
+	// Transport replaces default HTTP transport, e.g. for tracing or proxies
+	Transport          http.RoundTripper
 	DebugTruncateBytes int
 	DebugHeaders       bool
 	RateLimitPerSecond int
-	authMutex          sync.Mutex
-	rateLimiter        *rate.Limiter
-	Provider           *schema.Provider
-	httpClient         *retryablehttp.Client
-	authVisitor        func(r *http.Request) error
-	commandFactory     func(context.Context, *DatabricksClient) CommandExecutor
+	// DefaultClusterTags are added to clusters, that provider creates for itself, e.g. for mounting
+	DefaultClusterTags map[string]string
+	// ClusterCreateMaxAttempts bounds launches of a cluster, that failed to start because
+	// of transient cloud provider failure. Zero and one mean no retries.
+	ClusterCreateMaxAttempts int
+	// ScimBasePath overrides detected base path of SCIM API, e.g. with ScimPath
+	ScimBasePath string
+	// Cloud of the workspace, one of CloudAWS, CloudAzure or CloudGCP, that overrides
+	// detection from the host, e.g. for workspaces behind custom domain names
+	Cloud            string
+	authMutex        sync.Mutex
+	rateLimiter      *rate.Limiter
+	Provider         *schema.Provider
+	httpClient       *retryablehttp.Client
+	authVisitor      func(r *http.Request) error
+	commandFactory   func(context.Context, *DatabricksClient) CommandExecutor
+	scimMutex        sync.RWMutex
+	scimDetectedPath string
 }
 
 // Configure client to work
 func (c *DatabricksClient) Configure() error {
 	c.configureHTTPCLient()
 	c.AzureAuth.databricksClient = c
+	if c.Host == "" && c.AccountID != "" {
+		c.Host = "https://" + accountsHost
+	}
 	if c.DebugTruncateBytes == 0 {
 		c.DebugTruncateBytes = DefaultTruncateBytes
 	}
@@ -61,7 +86,7 @@ func (c *DatabricksClient) Configure() error {
 }
 
 // Authenticate authenticates across providers or returns error
-func (c *DatabricksClient) Authenticate() error {
+func (c *DatabricksClient) Authenticate(ctx context.Context) error {
 	if c.authVisitor != nil {
 		return nil
 	}
@@ -70,14 +95,15 @@ func (c *DatabricksClient) Authenticate() error {
 	if c.authVisitor != nil {
 		return nil
 	}
-	authorizers := []func() (func(r *http.Request) error, error){
+	authorizers := []func(context.Context) (func(r *http.Request) error, error){
 		c.configureAuthWithDirectParams,
 		c.AzureAuth.configureWithClientSecret,
+		c.AzureAuth.configureWithAzureManagedIdentity,
 		c.AzureAuth.configureWithAzureCLI,
 		c.configureFromDatabricksCfg,
 	}
 	for _, authProvider := range authorizers {
-		authorizer, err := authProvider()
+		authorizer, err := authProvider(ctx)
 		if err != nil {
 			return err
 		}
@@ -95,7 +121,8 @@ func (c *DatabricksClient) Authenticate() error {
 		"3. azure_databricks_workspace_id + AZ CLI authentication.\n" +
 		"4. azure_databricks_workspace_id + azure_client_id + azure_client_secret + azure_tenant_id " +
 		"for Azure Service Principal authentication.\n" +
-		"5. Run `databricks configure --token` that will create ~/.databrickscfg file.\n\n" +
+		"5. azure_databricks_workspace_id + azure_use_msi for Azure Managed Identity authentication.\n" +
+		"6. Run `databricks configure --token` that will create ~/.databrickscfg file.\n\n" +
 		"Please check https://registry.terraform.io/providers/databrickslabs/databricks/latest/docs#authentication for details")
 }
 
@@ -107,7 +134,7 @@ func (c *DatabricksClient) fixHost() {
 	}
 }
 
-func (c *DatabricksClient) configureAuthWithDirectParams() (func(r *http.Request) error, error) {
+func (c *DatabricksClient) configureAuthWithDirectParams(ctx context.Context) (func(r *http.Request) error, error) {
 	authType := "Bearer"
 	var needsHostBecause string
 	if c.Username != "" && c.Password != "" {
@@ -129,7 +156,7 @@ func (c *DatabricksClient) configureAuthWithDirectParams() (func(r *http.Request
 	return c.authorizer(authType, c.Token), nil
 }
 
-func (c *DatabricksClient) configureFromDatabricksCfg() (func(r *http.Request) error, error) {
+func (c *DatabricksClient) configureFromDatabricksCfg(ctx context.Context) (func(r *http.Request) error, error) {
 	configFile := c.ConfigFile
 	if configFile == "" {
 		configFile = "~/.databrickscfg"
@@ -201,23 +228,29 @@ func (c *DatabricksClient) configureHTTPCLient() {
 	c.rateLimiter = rate.NewLimiter(rate.Every(1*time.Second), c.RateLimitPerSecond)
 	// Set up a retryable HTTP Client to handle cases where the service returns
 	// a transient error on initial creation
-	retryDelayDuration := 10 * time.Second
+	if c.RetryDelay == 0 {
+		c.RetryDelay = DefaultRetryDelay
+	}
 	retryMaximumDuration := 5 * time.Minute
-	defaultTransport := http.DefaultTransport.(*http.Transport)
+	transport := c.Transport
+	if transport == nil {
+		defaultTransport := http.DefaultTransport.(*http.Transport)
+		transport = &http.Transport{
+			Proxy:                 defaultTransport.Proxy,
+			DialContext:           defaultTransport.DialContext,
+			MaxIdleConns:          defaultTransport.MaxIdleConns,
+			IdleConnTimeout:       defaultTransport.IdleConnTimeout * 3,
+			TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout * 3,
+			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: c.InsecureSkipVerify,
+			},
+		}
+	}
 	c.httpClient = &retryablehttp.Client{
 		HTTPClient: &http.Client{
-			Timeout: time.Duration(c.HTTPTimeoutSeconds) * time.Second,
-			Transport: &http.Transport{
-				Proxy:                 defaultTransport.Proxy,
-				DialContext:           defaultTransport.DialContext,
-				MaxIdleConns:          defaultTransport.MaxIdleConns,
-				IdleConnTimeout:       defaultTransport.IdleConnTimeout * 3,
-				TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout * 3,
-				ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: c.InsecureSkipVerify,
-				},
-			},
+			Timeout:   time.Duration(c.HTTPTimeoutSeconds) * time.Second,
+			Transport: transport,
 		},
 		CheckRetry: c.checkHTTPRetry,
 		// Using a linear retry rather than the default exponential retry
@@ -226,13 +259,77 @@ func (c *DatabricksClient) configureHTTPCLient() {
 		// to the same value removes jitter (which would be useful in a high-volume traffic scenario
 		// but wouldn't add much here)
 		Backoff:      retryablehttp.LinearJitterBackoff,
-		RetryWaitMin: retryDelayDuration,
-		RetryWaitMax: retryDelayDuration,
-		RetryMax:     int(retryMaximumDuration / retryDelayDuration),
+		RetryWaitMin: c.RetryDelay,
+		RetryWaitMax: c.RetryDelay,
+		// number of attempts doesn't depend on overridden delay
+		RetryMax: int(retryMaximumDuration / DefaultRetryDelay),
 	}
 }
 
 // IsAzure returns true if client is configured for Azure Databricks - either by using AAD auth or with host+token combination
 func (c *DatabricksClient) IsAzure() bool {
+	if c.Cloud != "" {
+		return c.Cloud == CloudAzure
+	}
 	return c.AzureAuth.resourceID() != "" || strings.Contains(c.Host, "azuredatabricks.net")
 }
+
+// Clouds, where Databricks workspaces are deployed
+const (
+	CloudAWS   = "aws"
+	CloudAzure = "azure"
+	CloudGCP   = "gcp"
+)
+
+var cloudTitles = map[string]string{
+	CloudAWS:   "an AWS",
+	CloudAzure: "an Azure",
+	CloudGCP:   "a GCP",
+}
+
+// DetectCloud returns the configured cloud of the workspace or detects it from the host
+// and Azure authentication. Empty string is returned if cloud is unknown, e.g. for hosts
+// with custom domain names, that are not yet known before authentication.
+func (c *DatabricksClient) DetectCloud() string {
+	switch {
+	case c.Cloud != "":
+		return c.Cloud
+	case c.IsAzure():
+		return CloudAzure
+	case strings.Contains(c.Host, ".gcp.databricks.com"):
+		return CloudGCP
+	case strings.Contains(c.Host, ".cloud.databricks.com"):
+		return CloudAWS
+	}
+	return ""
+}
+
+// ValidateCloud returns error, if the resource is used on a workspace in other cloud
+// than the one it supports. Workspaces in unknown cloud pass the validation.
+func (c *DatabricksClient) ValidateCloud(resource, cloud string) error {
+	detected := c.DetectCloud()
+	if detected == "" || detected == cloud {
+		return nil
+	}
+	title, ok := cloudTitles[detected]
+	if !ok {
+		title = detected
+	}
+	return fmt.Errorf("%s cannot be used on %s workspace", resource, title)
+}
+
+// IsAccountClient returns true if client is configured for account-level APIs
+func (c *DatabricksClient) IsAccountClient() bool {
+	return strings.Contains(c.Host, accountsHost)
+}
+
+// ValidateAccountClient returns error, if the account-level resource is used with a
+// client, that is configured for workspace host. Clients for unknown hosts pass.
+func (c *DatabricksClient) ValidateAccountClient(resource string) error {
+	if c.IsAccountClient() || c.DetectCloud() == "" {
+		return nil
+	}
+	return fmt.Errorf("%s requires host to be https://%s, but it is %s. Please configure "+
+		"account-level resources with a separate provider block, that sets account_id",
+		resource, accountsHost, c.Host)
+}
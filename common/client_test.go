@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -17,7 +18,7 @@ func configureAndAuthenticate(dc *DatabricksClient) (*DatabricksClient, error) {
 	if err != nil {
 		return dc, err
 	}
-	return dc, dc.Authenticate()
+	return dc, dc.Authenticate(context.Background())
 }
 
 func TestDatabricksClientConfigure_Nothing(t *testing.T) {
@@ -151,3 +152,55 @@ func TestDatabricksClientConfigure_InvalidConfigFilePath(t *testing.T) {
 // 	})
 // 	assert.EqualError(t, err, ".")
 // }
+
+func TestDatabricksClientDetectCloud(t *testing.T) {
+	for _, tc := range []struct {
+		client *DatabricksClient
+		cloud  string
+	}{
+		{&DatabricksClient{Cloud: CloudGCP, Host: "https://abc.cloud.databricks.com"}, CloudGCP},
+		{&DatabricksClient{Host: "https://adb-123.4.azuredatabricks.net"}, CloudAzure},
+		{&DatabricksClient{Host: "https://databricks.example.com", AzureAuth: AzureAuth{
+			ResourceID: "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c",
+		}}, CloudAzure},
+		{&DatabricksClient{Host: "https://123.4.gcp.databricks.com"}, CloudGCP},
+		{&DatabricksClient{Host: "https://abc.cloud.databricks.com"}, CloudAWS},
+		{&DatabricksClient{Host: "https://databricks.example.com"}, ""},
+	} {
+		assert.Equal(t, tc.cloud, tc.client.DetectCloud(), tc.client.Host)
+	}
+}
+
+func TestDatabricksClientIsAzure_ExplicitCloud(t *testing.T) {
+	dc := DatabricksClient{Cloud: CloudAWS, Host: "https://adb-123.4.azuredatabricks.net"}
+	assert.False(t, dc.IsAzure())
+}
+
+func TestDatabricksClientValidateCloud(t *testing.T) {
+	aws := DatabricksClient{Host: "https://abc.cloud.databricks.com"}
+	assert.NoError(t, aws.ValidateCloud("databricks_aws_s3_mount", CloudAWS))
+	assert.EqualError(t, aws.ValidateCloud("databricks_gcs_mount", CloudGCP),
+		"databricks_gcs_mount cannot be used on an AWS workspace")
+
+	custom := DatabricksClient{Host: "https://databricks.example.com"}
+	assert.NoError(t, custom.ValidateCloud("databricks_gcs_mount", CloudGCP))
+}
+
+func TestDatabricksClientConfigure_AccountID(t *testing.T) {
+	dc := DatabricksClient{AccountID: "abc"}
+	assert.NoError(t, dc.Configure())
+	assert.Equal(t, "https://accounts.cloud.databricks.com", dc.Host)
+	assert.True(t, dc.IsAccountClient())
+	assert.NoError(t, dc.ValidateAccountClient("databricks_mws_networks"))
+}
+
+func TestDatabricksClientValidateAccountClient(t *testing.T) {
+	workspace := DatabricksClient{Host: "https://abc.cloud.databricks.com"}
+	assert.EqualError(t, workspace.ValidateAccountClient("databricks_mws_networks"),
+		"databricks_mws_networks requires host to be https://accounts.cloud.databricks.com, "+
+			"but it is https://abc.cloud.databricks.com. Please configure account-level "+
+			"resources with a separate provider block, that sets account_id")
+
+	custom := DatabricksClient{Host: "https://databricks.example.com"}
+	assert.NoError(t, custom.ValidateAccountClient("databricks_mws_networks"))
+}
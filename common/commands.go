@@ -1,9 +1,121 @@
 package common
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// IPython's output prefixes
+	outRE = regexp.MustCompile(`Out\[[\d\s]+\]:\s`)
+	// HTML tags
+	tagRE = regexp.MustCompile(`<[^>]*>`)
+	// just exception content without exception name
+	exceptionRE = regexp.MustCompile(`.*Exception: (.*)`)
+	// execution errors resulting from http errors are sometimes hidden in these keys
+	executionErrorRE = regexp.MustCompile(`ExecutionError: ([\s\S]*)\n(StatusCode=[0-9]*)\n(StatusDescription=.*)\n`)
+	// usual error message explanation is hidden in this key
+	errorMessageRE = regexp.MustCompile(`ErrorMessage=(.+)\n`)
+)
+
+const (
+	// CommandStatusFinished is the status of command, that produced text results
+	CommandStatusFinished = "FINISHED"
+	// CommandStatusError is the status of command, that either failed or produced
+	// results of unexpected type
+	CommandStatusError = "ERROR"
+)
+
+// CommandResults captures results of a command, mirroring the execution context API.
+// Failed commands have `error` result type, while the text output has `text` type.
+// Results of any other type are treated as failures.
+type CommandResults struct {
+	Status       string      `json:"status,omitempty"`
+	ResultType   string      `json:"resultType,omitempty"`
+	Summary      string      `json:"summary,omitempty"`
+	Cause        string      `json:"cause,omitempty"`
+	Data         interface{} `json:"data,omitempty"`
+	Schema       interface{} `json:"schema,omitempty"`
+	Truncated    bool        `json:"truncated,omitempty"`
+	IsJSONSchema bool        `json:"isJsonSchema,omitempty"`
+}
+
+// Failed tells if command execution failed or returned results other than text
+func (cr *CommandResults) Failed() bool {
+	return cr.Status == CommandStatusError || cr.ResultType != "text"
+}
+
+// Text returns plain text results without IPython output prefixes
+func (cr *CommandResults) Text() string {
+	if cr.ResultType != "text" {
+		return ""
+	}
+	text, _ := cr.Data.(string)
+	return outRE.ReplaceAllLiteralString(text, "")
+}
+
+// Err returns error of the failed command or nil
+func (cr *CommandResults) Err() error {
+	if !cr.Failed() {
+		return nil
+	}
+	return errors.New(cr.Error())
+}
+
+// Error returns human-readable message of the failed command, extracted
+// either from exception summary or from the cause
+func (cr *CommandResults) Error() string {
+	if cr.ResultType != "error" {
+		return fmt.Sprintf("Unknown result type %s: %v", cr.ResultType, cr.Data)
+	}
+	summary := tagRE.ReplaceAllLiteralString(cr.Summary, "")
+	summary = html.UnescapeString(summary)
+
+	exceptionMatches := exceptionRE.FindStringSubmatch(summary)
+	if len(exceptionMatches) == 2 {
+		summary = strings.ReplaceAll(exceptionMatches[1], "; nested exception is:", "")
+		summary = strings.TrimRight(summary, " ")
+		return summary
+	}
+
+	executionErrorMatches := executionErrorRE.FindStringSubmatch(cr.Cause)
+	if len(executionErrorMatches) == 4 {
+		return strings.Join(executionErrorMatches[1:], "\n")
+	}
+
+	errorMessageMatches := errorMessageRE.FindStringSubmatch(cr.Cause)
+	if len(errorMessageMatches) == 2 {
+		return errorMessageMatches[1]
+	}
+
+	return summary
+}
 
 // WithCommandMock mocks all command executions for this client
 func (c *DatabricksClient) WithCommandMock(mock CommandMock) {
+	c.WithCommandResultsMock(func(commandStr string) CommandResults {
+		result, err := mock(commandStr)
+		if err != nil {
+			return CommandResults{
+				Status:     CommandStatusError,
+				ResultType: "error",
+				Summary:    err.Error(),
+			}
+		}
+		return CommandResults{
+			Status:     CommandStatusFinished,
+			ResultType: "text",
+			Data:       result,
+		}
+	})
+}
+
+// WithCommandResultsMock mocks all command executions for this client with structured results
+func (c *DatabricksClient) WithCommandResultsMock(mock CommandResultsMock) {
 	c.WithCommandExecutor(func(_ context.Context, _ *DatabricksClient) CommandExecutor {
 		return commandExecutorMock{
 			mock: mock,
@@ -21,20 +133,24 @@ func (c *DatabricksClient) CommandExecutor(ctx context.Context) CommandExecutor
 	return c.commandFactory(ctx, c)
 }
 
-// CommandMock mocks the execution of command
+// CommandMock mocks the execution of command with text output or error. It is kept
+// for simpler tests, where structured results are not needed
 type CommandMock func(commandStr string) (string, error)
 
+// CommandResultsMock mocks the execution of command with structured results
+type CommandResultsMock func(commandStr string) CommandResults
+
 // CommandExecutorMock simplifies command testing
 type commandExecutorMock struct {
-	mock CommandMock
+	mock CommandResultsMock
 }
 
 // Execute mock command with given mock function
-func (c commandExecutorMock) Execute(clusterID, language, commandStr string) (string, error) {
+func (c commandExecutorMock) Execute(clusterID, language, commandStr string) CommandResults {
 	return c.mock(commandStr)
 }
 
 // CommandExecutor creates a spark context and executes a command and then closes context
 type CommandExecutor interface {
-	Execute(clusterID, language, commandStr string) (string, error)
+	Execute(clusterID, language, commandStr string) CommandResults
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,9 +23,70 @@ func TestCommandMock(t *testing.T) {
 		return "done", nil
 	})
 	ctx := context.Background()
-	res, err := c.CommandExecutor(ctx).Execute("irrelevant", "python", "print 1")
+	res := c.CommandExecutor(ctx).Execute("irrelevant", "python", "print 1")
 
 	assert.Equal(t, true, called)
-	assert.Equal(t, "done", res)
-	assert.NoError(t, err, err)
+	assert.False(t, res.Failed())
+	assert.Equal(t, "done", res.Text())
+}
+
+func TestCommandMock_Error(t *testing.T) {
+	c := DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	assert.NoError(t, err)
+
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		return "", errors.New("Mount not found")
+	})
+	ctx := context.Background()
+	res := c.CommandExecutor(ctx).Execute("irrelevant", "python", "print 1")
+
+	assert.True(t, res.Failed())
+	assert.Equal(t, "", res.Text())
+	assert.EqualError(t, res.Err(), "Mount not found")
+}
+
+func TestCommandResults_Text(t *testing.T) {
+	res := CommandResults{
+		ResultType: "text",
+		Data:       "Out[1]: done",
+	}
+	assert.False(t, res.Failed())
+	assert.NoError(t, res.Err())
+	assert.Equal(t, "done", res.Text())
+}
+
+func TestCommandResults_UnknownType(t *testing.T) {
+	res := CommandResults{
+		ResultType: "html",
+		Data:       "<b>done</b>",
+	}
+	assert.True(t, res.Failed())
+	assert.Equal(t, "", res.Text())
+	assert.EqualError(t, res.Err(), "Unknown result type html: <b>done</b>")
+}
+
+func TestCommandMock_Status(t *testing.T) {
+	c := DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	assert.NoError(t, err)
+
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		if commandStr == "fail" {
+			return "", errors.New("Mount not found")
+		}
+		return "done", nil
+	})
+	ctx := context.Background()
+	res := c.CommandExecutor(ctx).Execute("irrelevant", "python", "print 1")
+	assert.Equal(t, CommandStatusFinished, res.Status)
+
+	res = c.CommandExecutor(ctx).Execute("irrelevant", "python", "fail")
+	assert.Equal(t, CommandStatusError, res.Status)
 }
@@ -2,6 +2,8 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -40,6 +42,13 @@ func addContextToStage(name string,
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		ctx = context.WithValue(ctx, ResourceName, name)
+		ctx = context.WithValue(ctx, CorrelationID, newCorrelationID(name))
 		return f(ctx, d, m)
 	}
 }
+
+// newCorrelationID returns random identifier of resource operation, so that
+// requests of concurrently applied resources could be told apart in logs
+func newCorrelationID(name string) string {
+	return fmt.Sprintf("%s-%08x", name, rand.Uint32())
+}
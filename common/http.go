@@ -65,6 +65,11 @@ func (apiError APIError) IsMissing() bool {
 	return apiError.StatusCode == http.StatusNotFound
 }
 
+// IsConflict tells if the resource already exists
+func (apiError APIError) IsConflict() bool {
+	return apiError.StatusCode == http.StatusConflict
+}
+
 // IsTooManyRequests shows rate exceeded limits
 func (apiError APIError) IsTooManyRequests() bool {
 	return apiError.StatusCode == http.StatusTooManyRequests
@@ -170,7 +175,8 @@ func (c *DatabricksClient) parseError(resp *http.Response) APIError {
 			Resource:   resp.Request.URL.Path,
 		}
 	}
-	log.Printf("[DEBUG] %s %v", resp.Status, c.redactedDump(body))
+	log.Printf("[DEBUG] [%s] %s%s %v", CorrelationID.GetOrUnknown(resp.Request.Context()),
+		resp.Status, requestIDOf(resp), c.redactedDump(body))
 	mwsError := c.commonErrorClarity(resp)
 	if mwsError != nil {
 		return *mwsError
@@ -206,6 +212,18 @@ func (c *DatabricksClient) parseError(resp *http.Response) APIError {
 func (c *DatabricksClient) checkHTTPRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
 	if ue, ok := err.(*url.Error); ok {
 		apiError := APIError{ErrorCode: "IO_ERROR", Message: ue.Error()}
+		if ue.Timeout() && !apiError.IsRetriable() {
+			// retrying stalled requests would only multiply the wait
+			return false, APIError{
+				ErrorCode: "TIMEOUT",
+				Message: fmt.Sprintf("Request timed out after %d seconds: %s",
+					c.HTTPTimeoutSeconds, ue.Error()),
+			}
+		}
+		if isScimRead(ue.Op, ue.URL) && (ue.Temporary() || ue.Timeout()) {
+			// timeouts, that are not retriable, fail fast above
+			return true, apiError
+		}
 		return apiError.IsRetriable(), apiError
 	}
 	if resp == nil {
@@ -222,11 +240,23 @@ func (c *DatabricksClient) checkHTTPRetry(ctx context.Context, resp *http.Respon
 	}
 	if resp.StatusCode >= 400 {
 		apiError := c.parseError(resp)
+		if resp.StatusCode >= 500 && resp.Request != nil &&
+			isScimRead(resp.Request.Method, resp.Request.URL.Path) {
+			log.Printf("[INFO] Retrying %s because of %s", resp.Request.URL.Path, resp.Status)
+			return true, apiError
+		}
 		return apiError.IsRetriable(), apiError
 	}
 	return false, nil
 }
 
+// isScimRead is true for SCIM GET requests, which are safe to retry on server
+// and temporary network errors, as SCIM API is known to fail under load
+func isScimRead(method, requestURL string) bool {
+	return strings.EqualFold(method, http.MethodGet) &&
+		strings.Contains(requestURL, "/scim/v2/")
+}
+
 // Get on path
 func (c *DatabricksClient) Get(ctx context.Context, path string, request interface{}, response interface{}) error {
 	body, err := c.authenticatedQuery(ctx, http.MethodGet, path, request, c.api2)
@@ -317,8 +347,37 @@ func (c *DatabricksClient) api12(r *http.Request) error {
 	return nil
 }
 
-// Scim sets SCIM headers
+const (
+	// ScimPreviewPath is the base path of SCIM API, that is used by default
+	ScimPreviewPath = "/preview/scim/v2"
+	// ScimPath is the base path of generally available SCIM API
+	ScimPath = "/scim/v2"
+)
+
+// Scim sets SCIM headers and performs the call on path relative to SCIM base path,
+// like `/Groups/abc`. Unless ScimBasePath is configured, base path is detected on
+// the first call, falling back to the other one, if SCIM endpoint is not found.
 func (c *DatabricksClient) Scim(ctx context.Context, method, path string, request interface{}, response interface{}) error {
+	basePath := c.scimBasePath()
+	err := c.scim(ctx, method, basePath+path, request, response)
+	if c.ScimBasePath != "" || !isEndpointNotFound(err) {
+		return err
+	}
+	fallbackPath := ScimPath
+	if basePath == ScimPath {
+		fallbackPath = ScimPreviewPath
+	}
+	log.Printf("[INFO] SCIM API is not found at %s, falling back to %s", basePath, fallbackPath)
+	err = c.scim(ctx, method, fallbackPath+path, request, response)
+	if !isEndpointNotFound(err) {
+		c.scimMutex.Lock()
+		c.scimDetectedPath = fallbackPath
+		c.scimMutex.Unlock()
+	}
+	return err
+}
+
+func (c *DatabricksClient) scim(ctx context.Context, method, path string, request interface{}, response interface{}) error {
 	body, err := c.authenticatedQuery(ctx, method, path, request, c.api2, func(r *http.Request) error {
 		r.Header.Set("Content-Type", "application/scim+json")
 		return nil
@@ -329,6 +388,26 @@ func (c *DatabricksClient) Scim(ctx context.Context, method, path string, reques
 	return c.unmarshall(path, body, &response)
 }
 
+// scimBasePath returns configured or previously detected SCIM base path
+func (c *DatabricksClient) scimBasePath() string {
+	if c.ScimBasePath != "" {
+		return c.ScimBasePath
+	}
+	c.scimMutex.RLock()
+	defer c.scimMutex.RUnlock()
+	if c.scimDetectedPath != "" {
+		return c.scimDetectedPath
+	}
+	return ScimPreviewPath
+}
+
+// isEndpointNotFound is true, when API itself is missing, as opposed to
+// 404 for a missing entity
+func isEndpointNotFound(err error) bool {
+	apiErr, ok := err.(APIError)
+	return ok && apiErr.StatusCode == 404 && apiErr.ErrorCode == "ENDPOINT_NOT_FOUND"
+}
+
 // OldAPI performs call on context api
 func (c *DatabricksClient) OldAPI(ctx context.Context, method, path string, request interface{}, response interface{}) error {
 	body, err := c.authenticatedQuery(ctx, method, path, request, c.api12)
@@ -340,7 +419,7 @@ func (c *DatabricksClient) OldAPI(ctx context.Context, method, path string, requ
 
 func (c *DatabricksClient) authenticatedQuery(ctx context.Context, method, requestURL string,
 	data interface{}, visitors ...func(*http.Request) error) (body []byte, err error) {
-	err = c.Authenticate()
+	err = c.Authenticate(ctx)
 	if err != nil {
 		return
 	}
@@ -366,10 +445,8 @@ func (c *DatabricksClient) recursiveMask(requestMap map[string]interface{}) inte
 			requestMap[k] = c.recursiveMask(m)
 			continue
 		}
-		// todo: dapi...
-		// TODO: just redact any dapiXXX & "secret": "...."...
 		if s, ok := v.(string); ok {
-			requestMap[k] = onlyNBytes(s, c.DebugTruncateBytes)
+			requestMap[k] = onlyNBytes(Redact(s), c.DebugTruncateBytes)
 		}
 	}
 	return requestMap
@@ -439,7 +516,8 @@ func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL
 			headers += "\n"
 		}
 	}
-	log.Printf("[DEBUG] %s %s %s%v", method, requestURL, headers, c.redactedDump(requestBody))
+	correlationID := CorrelationID.GetOrUnknown(ctx)
+	log.Printf("[DEBUG] [%s] %s %s %s%v", correlationID, method, requestURL, headers, c.redactedDump(requestBody))
 
 	r, err := retryablehttp.FromRequest(request)
 	if err != nil {
@@ -463,7 +541,8 @@ func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] %s %v <- %s %s", resp.Status, c.redactedDump(body), method, requestURL)
+	log.Printf("[DEBUG] [%s] %s%s %v <- %s %s", correlationID, resp.Status, requestIDOf(resp),
+		c.redactedDump(body), method, requestURL)
 	return body, nil
 }
 
@@ -510,6 +589,16 @@ func makeRequestBody(method string, requestURL *string, data interface{}, marsha
 	return requestBody, nil
 }
 
+// requestIDOf returns formatted `x-request-id` header of Databricks API response,
+// which helps support to find the request, or empty string if there's none
+func requestIDOf(resp *http.Response) string {
+	requestID := resp.Header.Get("X-Request-Id")
+	if requestID == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (x-request-id: %s)", requestID)
+}
+
 func onlyNBytes(j string, numBytes int) string {
 	diff := len([]byte(j)) - numBytes
 	if diff > 0 {
@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -202,6 +205,78 @@ func TestCheckHTTPRetry_429(t *testing.T) {
 		"Actual message: %s", err.Error())
 }
 
+func TestCheckHTTPRetry_ScimRead5xx(t *testing.T) {
+	ws := DatabricksClient{
+		Host: "qwerty.cloud.databricks.com",
+	}
+	for method, expected := range map[string]bool{
+		"GET":   true,
+		"PATCH": false,
+	} {
+		retry, err := ws.checkHTTPRetry(context.Background(), &http.Response{
+			StatusCode: 503,
+			Status:     "503 Service Unavailable",
+			Request: httptest.NewRequest(method,
+				"https://qwerty.cloud.databricks.com/api/2.0/preview/scim/v2/Groups/abc", nil),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte(`{
+				"error_code": "TEMPORARILY_UNAVAILABLE",
+				"message": "Please try again later"
+			}`))),
+		}, nil)
+		assert.Equal(t, expected, retry, method)
+		assert.EqualError(t, err, "Please try again later")
+	}
+}
+
+func TestCheckHTTPRetry_ScimRead404(t *testing.T) {
+	ws := DatabricksClient{
+		Host: "qwerty.cloud.databricks.com",
+	}
+	retry, err := ws.checkHTTPRetry(context.Background(), &http.Response{
+		StatusCode: 404,
+		Request: httptest.NewRequest("GET",
+			"https://qwerty.cloud.databricks.com/api/2.0/preview/scim/v2/Groups/abc", nil),
+		Body: ioutil.NopCloser(bytes.NewReader([]byte(`{
+			"detail": "Group with id abc not found."
+		}`))),
+	}, nil)
+	assert.False(t, retry)
+	assert.EqualError(t, err, "Group with id abc not found.")
+}
+
+// temporaryError is a network error, that is expected to go away on retry
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "network is temporarily unreachable" }
+func (temporaryError) Temporary() bool { return true }
+
+func TestCheckHTTPRetry_ScimReadNetworkError(t *testing.T) {
+	ws := DatabricksClient{
+		Host: "qwerty.cloud.databricks.com",
+	}
+	retry, err := ws.checkHTTPRetry(context.Background(), nil, &url.Error{
+		Op:  "Get",
+		URL: "https://qwerty.cloud.databricks.com/api/2.0/preview/scim/v2/Groups/abc",
+		Err: temporaryError{},
+	})
+	assert.True(t, retry, "temporary network errors are retried")
+	assert.Error(t, err)
+}
+
+func TestCheckHTTPRetry_ScimReadPermanentNetworkError(t *testing.T) {
+	ws := DatabricksClient{
+		Host: "qwerty.cloud.databricks.com",
+	}
+	retry, err := ws.checkHTTPRetry(context.Background(), nil, &url.Error{
+		Op:  "Get",
+		URL: "https://qwerty.cloud.databricks.com/api/2.0/preview/scim/v2/Groups/abc",
+		Err: fmt.Errorf("x509: certificate signed by unknown authority"),
+	})
+	assert.False(t, retry)
+	assert.EqualError(t, err, "Get \"https://qwerty.cloud.databricks.com/api/2.0/preview/scim/v2/Groups/abc\": "+
+		"x509: certificate signed by unknown authority")
+}
+
 func singleRequestServer(t *testing.T, method, url, response string) (*DatabricksClient, *httptest.Server) {
 	server := httptest.NewServer(http.HandlerFunc(
 		func(rw http.ResponseWriter, req *http.Request) {
@@ -304,7 +379,7 @@ func TestAPI2(t *testing.T) {
 }
 
 func TestScim(t *testing.T) {
-	ws, server := singleRequestServer(t, "GET", "/api/2.0/imaginary/endpoint", `{"a": "b"}`)
+	ws, server := singleRequestServer(t, "GET", "/api/2.0/preview/scim/v2/imaginary/endpoint", `{"a": "b"}`)
 	defer server.Close()
 
 	var resp map[string]string
@@ -312,6 +387,179 @@ func TestScim(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func scimFallbackServer(t *testing.T, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			*calls = append(*calls, req.RequestURI)
+			switch req.RequestURI {
+			case "/api/2.0/preview/scim/v2/Groups/abc":
+				rw.WriteHeader(404)
+				_, err := rw.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND",
+					"message": "No API found for 'GET /preview/scim/v2/Groups/abc'"}`))
+				assert.NoError(t, err)
+			case "/api/2.0/scim/v2/Groups/abc":
+				_, err := rw.Write([]byte(`{"id": "abc"}`))
+				assert.NoError(t, err)
+			case "/api/2.0/scim/v2/Groups/missing":
+				rw.WriteHeader(404)
+				_, err := rw.Write([]byte(`{"detail": "Group with id missing not found.", "status": "404"}`))
+				assert.NoError(t, err)
+			default:
+				assert.Fail(t, fmt.Sprintf("Received unexpected call: %s %s",
+					req.Method, req.RequestURI))
+			}
+		}))
+}
+
+func TestScim_FallbackOnEndpointNotFound(t *testing.T) {
+	var calls []string
+	server := scimFallbackServer(t, &calls)
+	defer server.Close()
+	client := &DatabricksClient{
+		Host:  server.URL + "/",
+		Token: "..",
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	var resp map[string]string
+	err = client.Scim(context.Background(), "GET", "/Groups/abc", nil, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", resp["id"])
+
+	// detected base path is reused and missing entities do not trigger fallback
+	err = client.Scim(context.Background(), "GET", "/Groups/missing", nil, &resp)
+	require.Error(t, err)
+	assert.True(t, err.(APIError).IsMissing())
+	assert.Equal(t, []string{
+		"/api/2.0/preview/scim/v2/Groups/abc",
+		"/api/2.0/scim/v2/Groups/abc",
+		"/api/2.0/scim/v2/Groups/missing",
+	}, calls)
+}
+
+func TestScim_ConfiguredBasePathHasNoFallback(t *testing.T) {
+	var calls []string
+	server := scimFallbackServer(t, &calls)
+	defer server.Close()
+	client := &DatabricksClient{
+		Host:         server.URL + "/",
+		Token:        "..",
+		ScimBasePath: ScimPreviewPath,
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	err = client.Scim(context.Background(), "GET", "/Groups/abc", nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, []string{"/api/2.0/preview/scim/v2/Groups/abc"}, calls)
+}
+
+func TestGenericQuery_LogsCorrelationAndRequestIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("X-Request-Id", "12345-abcde")
+			if req.RequestURI == "/api/2.0/clusters/get?cluster_id=missing" {
+				rw.WriteHeader(404)
+				_, err := rw.Write([]byte(`{"error_code": "NOT_FOUND", "message": "Nope"}`))
+				assert.NoError(t, err)
+				return
+			}
+			_, err := rw.Write([]byte(`{"token_value": "very-secret"}`))
+			assert.NoError(t, err)
+		}))
+	defer server.Close()
+	client := &DatabricksClient{
+		Host:  server.URL + "/",
+		Token: "..",
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx := context.WithValue(context.Background(), CorrelationID, "aws_s3_mount-0000beef")
+	var resp map[string]string
+	err = client.Get(ctx, "/token/create", nil, &resp)
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "[DEBUG] [aws_s3_mount-0000beef] GET /token/create")
+	assert.Contains(t, logs.String(), "[DEBUG] [aws_s3_mount-0000beef] 200 OK (x-request-id: 12345-abcde)")
+	assert.NotContains(t, logs.String(), "very-secret")
+
+	logs.Reset()
+	err = client.Get(ctx, "/clusters/get", map[string]string{"cluster_id": "missing"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, logs.String(), "[DEBUG] [aws_s3_mount-0000beef] 404 Not Found (x-request-id: 12345-abcde)")
+}
+
+func TestNewCorrelationID(t *testing.T) {
+	id := newCorrelationID("mount")
+	assert.Regexp(t, `^mount-[0-9a-f]{8}$`, id)
+	assert.NotEqual(t, id, newCorrelationID("mount"))
+}
+
+func TestClient_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			time.Sleep(1500 * time.Millisecond)
+			_, err := rw.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		}))
+	defer server.Close()
+	client := &DatabricksClient{
+		Host:               server.URL + "/",
+		Token:              "..",
+		HTTPTimeoutSeconds: 1,
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	err = client.Get(context.Background(), "/clusters/list", nil, nil)
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "Request timed out after 1 seconds"),
+		"Actual message: %s", err.Error())
+
+	var resp map[string]string
+	err = client.Scim(context.Background(), "GET", "/Groups", nil, &resp)
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "Request timed out after 1 seconds"),
+		"Actual message: %s", err.Error())
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClient_CustomTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			_, err := rw.Write([]byte(`{"a": "b"}`))
+			assert.NoError(t, err)
+		}))
+	defer server.Close()
+	transport := &countingTransport{}
+	client := &DatabricksClient{
+		Host:      server.URL + "/",
+		Token:     "..",
+		Transport: transport,
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	var resp map[string]string
+	err = client.Get(context.Background(), "/imaginary/endpoint", nil, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "b", resp["a"])
+	assert.Equal(t, 1, transport.requests)
+}
+
 func TestOldAPI(t *testing.T) {
 	ws, server := singleRequestServer(t, "GET", "/api/1.2/imaginary/endpoint", `{"a": "b"}`)
 	defer server.Close()
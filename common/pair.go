@@ -16,7 +16,7 @@ type Pair struct {
 	schema      map[string]*schema.Schema
 }
 
-// NewPairID creates new ID pair
+// NewPairID creates new ID pair. Separator and backslash within ID parts are escaped with backslash.
 func NewPairID(left, right string) *Pair {
 	return NewPairSeparatedID(left, right, "|")
 }
@@ -40,10 +40,52 @@ func (p *Pair) Schema(do func(map[string]*schema.Schema) map[string]*schema.Sche
 	return p
 }
 
+// escape prefixes separator and backslash with backslash
+func (p *Pair) escape(part string) string {
+	part = strings.ReplaceAll(part, `\`, `\\`)
+	return strings.ReplaceAll(part, p.separator, `\`+p.separator)
+}
+
+// split ID in the escaped format, if it could have been created by Pack, otherwise
+// split it on the first separator as is, so that IDs created before escaping was
+// introduced, e.g. with backslashes in the left part, are parsed in the same way
+func (p *Pair) split(id string) []string {
+	if strings.Contains(id, `\`) {
+		parts := p.unescapeSplit(id)
+		if len(parts) == 2 && p.escape(parts[0])+p.separator+p.escape(parts[1]) == id {
+			return parts
+		}
+	}
+	return strings.SplitN(id, p.separator, 2)
+}
+
+// unescapeSplit splits ID on the first unescaped separator and unescapes both parts
+func (p *Pair) unescapeSplit(id string) (parts []string) {
+	var part strings.Builder
+	for i := 0; i < len(id); {
+		switch {
+		case len(parts) == 0 && strings.HasPrefix(id[i:], p.separator):
+			parts = append(parts, part.String())
+			part.Reset()
+			i += len(p.separator)
+		case id[i] == '\\' && strings.HasPrefix(id[i+1:], `\`):
+			part.WriteByte('\\')
+			i += 2
+		case id[i] == '\\' && strings.HasPrefix(id[i+1:], p.separator):
+			part.WriteString(p.separator)
+			i += 1 + len(p.separator)
+		default:
+			part.WriteByte(id[i])
+			i++
+		}
+	}
+	return append(parts, part.String())
+}
+
 // Unpack ID into two strings and set data
 func (p *Pair) Unpack(d *schema.ResourceData) (string, string, error) {
 	id := d.Id()
-	parts := strings.SplitN(id, p.separator, 2)
+	parts := p.split(id)
 	if len(parts) != 2 {
 		d.SetId("")
 		return "", "", fmt.Errorf("Invalid ID: %s", id)
@@ -71,7 +113,8 @@ func (p *Pair) Unpack(d *schema.ResourceData) (string, string, error) {
 
 // Pack data attributes to ID
 func (p *Pair) Pack(d *schema.ResourceData) {
-	d.SetId(fmt.Sprintf("%s%s%v", d.Get(p.left), p.separator, d.Get(p.right)))
+	d.SetId(p.escape(fmt.Sprintf("%v", d.Get(p.left))) + p.separator +
+		p.escape(fmt.Sprintf("%v", d.Get(p.right))))
 }
 
 // BindResource defines resource with simplified functions
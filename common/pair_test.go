@@ -110,6 +110,26 @@ func TestPairIDResource(t *testing.T) {
 			right:    "b",
 			assertID: "a|b",
 		},
+		{
+			create:   true,
+			left:     "a|x",
+			right:    `b\c|d`,
+			assertID: `a\|x|b\\c\|d`,
+		},
+		{
+			read:     true,
+			id:       `a\|x|b\\c\|d`,
+			left:     "a|x",
+			right:    `b\c|d`,
+			assertID: `a\|x|b\\c\|d`,
+		},
+		{
+			read:     true,
+			id:       `a\|b`,
+			left:     `a\`,
+			right:    "b",
+			assertID: `a\|b`,
+		},
 		{
 			create:      true,
 			left:        "a",
@@ -184,3 +204,50 @@ func TestPairIDResource(t *testing.T) {
 		})
 	}
 }
+
+func TestPairIDSchemaUnpackRoundTrip(t *testing.T) {
+	for _, separator := range []string{"|", "|||", "/"} {
+		p := NewPairSeparatedID("scope", "key", separator).Schema(
+			func(m map[string]*schema.Schema) map[string]*schema.Schema {
+				m["key"].Description = "customized"
+				return m
+			})
+		resource := p.BindResource(BindResource{})
+		assert.Equal(t, "customized", resource.Schema["key"].Description)
+
+		left := fmt.Sprintf(`a%sb\c`, separator)
+		right := fmt.Sprintf(`%sd%s`, separator, separator)
+		d := resource.TestResourceData()
+		require.NoError(t, d.Set("scope", left))
+		require.NoError(t, d.Set("key", right))
+		p.Pack(d)
+
+		imported := resource.TestResourceData()
+		imported.SetId(d.Id())
+		unpackedLeft, unpackedRight, err := p.Unpack(imported)
+		require.NoError(t, err, separator)
+		assert.Equal(t, left, unpackedLeft, separator)
+		assert.Equal(t, right, unpackedRight, separator)
+		assert.Equal(t, left, imported.Get("scope"), separator)
+		assert.Equal(t, right, imported.Get("key"), separator)
+	}
+}
+
+func TestPairIDUnpack_LegacyWithBackslash(t *testing.T) {
+	p := NewPairID("left_id", "right_id")
+	resource := p.BindResource(BindResource{})
+	for id, expected := range map[string][2]string{
+		`domain\user|abc`:  {`domain\user`, "abc"},
+		`ends\|abc`:        {`ends\`, "abc"},
+		`a\b|c|d`:          {`a\b`, "c|d"},
+		`domain\\user|abc`: {`domain\user`, "abc"},
+		`a\|b|c`:           {"a|b", "c"},
+	} {
+		d := resource.TestResourceData()
+		d.SetId(id)
+		left, right, err := p.Unpack(d)
+		require.NoError(t, err, id)
+		assert.Equal(t, expected[0], left, id)
+		assert.Equal(t, expected[1], right, id)
+	}
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"regexp"
+)
+
+// Redacted replaces credentials in logs and error messages
+const Redacted = "***"
+
+type redaction struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+var redactions = []redaction{
+	// tokens in authorization headers
+	{regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`), "${1}" + Redacted},
+	// personal access tokens
+	{regexp.MustCompile(`dapi[0-9a-f]{32}(-\d+)?`), Redacted},
+	// AWS access key ids
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), Redacted},
+	// values of sensitive configuration keys, like the ones echoed back by spark. References
+	// to secret scopes, like {{secrets/scope/key}}, are kept, as they help troubleshooting.
+	{regexp.MustCompile(`(?i)([\w.\-]*(?:secret|password|token|credential|account\.key|` +
+		`private\.key|access\.key)[\w.\-]*["']?\s*[:=]\s*["']?)[^"'\s,{}]+`), "${1}" + Redacted},
+}
+
+// Redact replaces known patterns of credentials in the text with ***
+func Redact(text string) string {
+	for _, r := range redactions {
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	return text
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e redactedError) Error() string {
+	return e.message
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactError returns the error with credentials removed from its message. Errors
+// without credentials are returned as is, so that they could still be compared.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	redacted := Redact(message)
+	if message == redacted {
+		return err
+	}
+	return redactedError{redacted, err}
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+)
+
+var (
+	// delay before the first retry, that is doubled on every attempt
+	retryMinBackoff = 1 * time.Second
+	// upper bound of delay between attempts
+	retryMaxBackoff = 30 * time.Second
+)
+
+// RetryClassifier tells if the error is transient and the call has to be retried.
+// Errors, for which it returns false, are terminal and returned right away.
+type RetryClassifier func(err error) bool
+
+// Retry calls fn until it succeeds, fails with terminal error or the context is done.
+// Delays between attempts grow exponentially and have random jitter, so that
+// concurrent resources do not retry in lockstep. Nil classifier retries all errors.
+func Retry(ctx context.Context, retryable RetryClassifier, fn func() error) error {
+	return RetryPolicy{}.Retry(ctx, retryable, fn)
+}
+
+// RetryPolicy bounds the number of attempts and delays between them. Zero values
+// mean unlimited attempts and default backoff.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Retry calls fn according to the policy and returns the last error, once
+// there are no attempts left
+func (p RetryPolicy) Retry(ctx context.Context, retryable RetryClassifier, fn func() error) error {
+	backoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if backoff == 0 {
+		backoff = retryMinBackoff
+	}
+	if maxBackoff == 0 {
+		maxBackoff = retryMaxBackoff
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if retryable != nil && !retryable(err) {
+			return err
+		}
+		if p.MaxAttempts == 1 {
+			return err
+		}
+		if p.MaxAttempts > 1 && attempt >= p.MaxAttempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+		}
+		delay := jitter(backoff)
+		log.Printf("[DEBUG] Attempt %d failed, retrying in %s: %s", attempt, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %s", ctx.Err(), err)
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// jitter returns random duration between half and the whole of the given one
+func jitter(d time.Duration) time.Duration {
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
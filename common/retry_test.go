@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fastRetries() func() {
+	minBackoff, maxBackoff := retryMinBackoff, retryMaxBackoff
+	retryMinBackoff = time.Millisecond
+	retryMaxBackoff = 4 * time.Millisecond
+	return func() {
+		retryMinBackoff, retryMaxBackoff = minBackoff, maxBackoff
+	}
+}
+
+var errTransient = errors.New("transient")
+
+func isTransient(err error) bool {
+	return errors.Is(err, errTransient)
+}
+
+func TestRetry_SucceedsAfterRetries(t *testing.T) {
+	defer fastRetries()()
+	attempts := 0
+	err := Retry(context.Background(), isTransient, func() error {
+		attempts++
+		if attempts < 5 {
+			return fmt.Errorf("attempt %d: %w", attempts, errTransient)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, attempts)
+}
+
+func TestRetry_TerminalErrorReturnedImmediately(t *testing.T) {
+	defer fastRetries()()
+	attempts := 0
+	err := Retry(context.Background(), isTransient, func() error {
+		attempts++
+		return errors.New("terminal")
+	})
+	assert.EqualError(t, err, "terminal")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetry_NilClassifierRetriesAll(t *testing.T) {
+	defer fastRetries()()
+	attempts := 0
+	err := Retry(context.Background(), nil, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("any")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetry_ContextCancelled(t *testing.T) {
+	defer fastRetries()()
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := Retry(ctx, isTransient, func() error {
+		attempts++
+		if attempts == 2 {
+			cancel()
+		}
+		return errTransient
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.EqualError(t, err, "context canceled: transient")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetry_DeadlineExceeded(t *testing.T) {
+	defer fastRetries()()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := Retry(ctx, isTransient, func() error {
+		return errTransient
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRetryPolicy_MaxAttempts(t *testing.T) {
+	attempts := 0
+	err := RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+	}.Retry(context.Background(), isTransient, func() error {
+		attempts++
+		return errTransient
+	})
+	assert.EqualError(t, err, "failed after 3 attempts: transient")
+	assert.True(t, errors.Is(err, errTransient))
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryPolicy_SingleAttempt(t *testing.T) {
+	attempts := 0
+	err := RetryPolicy{MaxAttempts: 1}.Retry(context.Background(), nil, func() error {
+		attempts++
+		return errTransient
+	})
+	assert.EqualError(t, err, "transient")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitter(10 * time.Second)
+		assert.True(t, d >= 5*time.Second && d < 10*time.Second, d)
+	}
+	assert.Equal(t, time.Duration(1), jitter(1))
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SecretReferenceFormat is the syntax of references to secrets within configuration
+// values, that are resolved by Databricks instead of being stored in plain text
+type SecretReferenceFormat struct {
+	re     *regexp.Regexp
+	format string
+}
+
+var (
+	// ClusterSecretReference is `{{secrets/<scope>/<key>}}`, resolved on cluster launch
+	ClusterSecretReference = SecretReferenceFormat{
+		re:     regexp.MustCompile(`^\{\{secrets/([^/]+)/([^/}]+)\}\}$`),
+		format: "{{secrets/%s/%s}}",
+	}
+	// MountSecretReference is `{secrets/<scope>/<key>}`, resolved with `dbutils.secrets.get`
+	// on the mounting cluster
+	MountSecretReference = SecretReferenceFormat{
+		re:     regexp.MustCompile(`^\{secrets/([^/]+)/([^/}]+)\}$`),
+		format: "{secrets/%s/%s}",
+	}
+)
+
+// Parse returns scope and key of the secret, if value is a reference in this format
+func (f SecretReferenceFormat) Parse(value string) (scope, key string, ok bool) {
+	match := f.re.FindStringSubmatch(value)
+	if len(match) != 3 {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// Format returns reference to the secret in this format
+func (f SecretReferenceFormat) Format(scope, key string) string {
+	return fmt.Sprintf(f.format, scope, key)
+}
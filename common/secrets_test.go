@@ -0,0 +1,32 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretReferenceFormat_Parse(t *testing.T) {
+	scope, key, ok := ClusterSecretReference.Parse("{{secrets/a/b}}")
+	assert.True(t, ok)
+	assert.Equal(t, "a", scope)
+	assert.Equal(t, "b", key)
+
+	_, _, ok = ClusterSecretReference.Parse("{secrets/a/b}")
+	assert.False(t, ok)
+
+	scope, key, ok = MountSecretReference.Parse("{secrets/a/b}")
+	assert.True(t, ok)
+	assert.Equal(t, "a", scope)
+	assert.Equal(t, "b", key)
+
+	for _, value := range []string{"{{secrets/a/b}}", "prefix {secrets/a/b}", "{secrets/a/b/c}", "plain"} {
+		_, _, ok = MountSecretReference.Parse(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestSecretReferenceFormat_Format(t *testing.T) {
+	assert.Equal(t, "{{secrets/a/b}}", ClusterSecretReference.Format("a", "b"))
+	assert.Equal(t, "{secrets/a/b}", MountSecretReference.Format("a", "b"))
+}
@@ -10,6 +10,8 @@ var (
 	Provider contextKey = 2
 	// Current is the current name of integration test
 	Current contextKey = 3
+	// CorrelationID identifies resource operation in logs of all requests it makes
+	CorrelationID contextKey = 4
 )
 
 type contextKey int
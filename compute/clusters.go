@@ -2,8 +2,10 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -31,11 +33,62 @@ type ClustersAPI struct {
 	context context.Context
 }
 
-// Create creates a new Spark cluster and waits till it's running
+var (
+	// delay before relaunching a cluster, that failed to start
+	clusterLaunchMinBackoff = 30 * time.Second
+	// upper bound of delay between cluster launches
+	clusterLaunchMaxBackoff = 5 * time.Minute
+)
+
+// retryableLaunchCodes are termination reason codes of transient failures, after
+// which a new cluster may successfully start. All other codes, like
+// INSTANCE_POOL_NOT_FOUND or INVALID_ARGUMENT, won't be fixed by a relaunch.
+var retryableLaunchCodes = map[string]bool{
+	"CLOUD_PROVIDER_LAUNCH_FAILURE":              true,
+	"CLOUD_PROVIDER_RESOURCE_STOCKOUT":           true,
+	"AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE": true,
+	"AZURE_RESOURCE_PROVIDER_THROTTLING":         true,
+	"SPOT_INSTANCE_TERMINATION":                  true,
+	"INSTANCE_UNREACHABLE":                       true,
+	"DRIVER_UNREACHABLE":                         true,
+	"COMMUNICATION_LOST":                         true,
+}
+
+// isRetryableLaunchFailure tells if cluster failed to start because of transient
+// cloud provider failure
+func isRetryableLaunchFailure(err error) bool {
+	var cue ClusterUnavailableError
+	if !errors.As(err, &cue) || cue.TerminationReason == nil {
+		return false
+	}
+	return retryableLaunchCodes[cue.TerminationReason.Code]
+}
+
+// Create creates a new Spark cluster and waits till it's running. Clusters, that
+// failed to start because of transient launch failures, are deleted and created
+// again up to the configured number of attempts.
 func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
+	policy := common.RetryPolicy{
+		MaxAttempts: a.client.ClusterCreateMaxAttempts,
+		MinBackoff:  clusterLaunchMinBackoff,
+		MaxBackoff:  clusterLaunchMaxBackoff,
+	}
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	err = policy.Retry(a.context, isRetryableLaunchFailure, func() error {
+		var createErr error
+		info, createErr = a.createAndWait(cluster)
+		return createErr
+	})
+	return
+}
+
+func (a ClustersAPI) createAndWait(cluster Cluster) (info ClusterInfo, err error) {
 	var ci ClusterID
 	err = a.client.Post(a.context, "/clusters/create", cluster, &ci)
 	if err != nil {
+		err = wrapPolicyViolationError(err, cluster.PolicyID)
 		return
 	}
 	info, err = a.waitForClusterStatus(ci.ClusterID, ClusterStateRunning)
@@ -80,7 +133,7 @@ func (a ClustersAPI) Edit(cluster Cluster) (info ClusterInfo, err error) {
 	}
 	err = a.client.Post(a.context, "/clusters/edit", cluster, nil)
 	if err != nil {
-		return info, err
+		return info, wrapPolicyViolationError(err, cluster.PolicyID)
 	}
 	if info.IsRunningOrResizing() {
 		// so if cluster was running, we'll start and wait again
@@ -162,10 +215,37 @@ func wrapMissingClusterError(err error, id string) error {
 	return err
 }
 
+// policyViolationRE matches single policy violation from cluster validation error,
+// like `Validation failed for spark_version, the value must be 7.3.x-scala2.12 (is "6.4.x-scala2.11")`
+var policyViolationRE = regexp.MustCompile(`Validation failed for ([^,]+), (.+)`)
+
+// wrapPolicyViolationError makes policy validation errors readable by listing every
+// offending attribute on its own line, along with the allowed value
+func wrapPolicyViolationError(err error, policyID string) error {
+	apiErr, ok := err.(common.APIError)
+	if !ok {
+		return err
+	}
+	violations := []string{}
+	for _, part := range strings.Split(apiErr.Message, "; ") {
+		match := policyViolationRE.FindStringSubmatch(part)
+		if len(match) != 3 {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf(" * %s: %s", match[1], match[2]))
+	}
+	if len(violations) == 0 {
+		return err
+	}
+	apiErr.Message = fmt.Sprintf("Cluster definition violates policy %s:\n%s",
+		policyID, strings.Join(violations, "\n"))
+	return apiErr
+}
+
 func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState) (result ClusterInfo, err error) {
 	// this tangles client with terraform more, which is inevitable
 	// nolint should be a bigger context-aware refactor
-	return result, resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
+	err = resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
 		clusterInfo, err := a.Get(clusterID)
 		if ae, ok := err.(common.APIError); ok && ae.IsMissing() {
 			log.Printf("[INFO] Cluster %s not found. Retrying", clusterID)
@@ -180,15 +260,83 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 			return nil
 		}
 		if !clusterInfo.State.CanReach(desired) {
-			docLink := "https://docs.databricks.com/dev-tools/api/latest/clusters.html#clusterclusterstate"
-			return resource.NonRetryableError(fmt.Errorf(
-				"%s is not able to transition from %s to %s: %s. Please see %s for more details",
-				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage, docLink))
+			return resource.NonRetryableError(newClusterUnavailableError(clusterID, clusterInfo, desired))
 		}
 		return resource.RetryableError(
 			fmt.Errorf("%s is %s, but has to be %s",
 				clusterID, clusterInfo.State, desired))
 	})
+	return result, withDeadlineError(a.context, err)
+}
+
+// withDeadlineError wraps error of the wait with the context error, once timeout
+// of the operation is over, as retries return only the last observed error
+func withDeadlineError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ctx.Err(), err)
+}
+
+// ClusterUnavailableError is returned, when cluster cannot reach desired state from
+// the current one, so that callers could decide whether to wait, retry or fail
+type ClusterUnavailableError struct {
+	ClusterID         string
+	State             ClusterState
+	Desired           ClusterState
+	StateMessage      string
+	TerminationReason *TerminationReason
+
+	hint string
+}
+
+func newClusterUnavailableError(clusterID string, ci ClusterInfo, desired ClusterState) ClusterUnavailableError {
+	return ClusterUnavailableError{
+		ClusterID:         clusterID,
+		State:             ci.State,
+		Desired:           desired,
+		StateMessage:      ci.StateMessage,
+		TerminationReason: ci.TerminationReason,
+		hint:              spotCapacityHint(ci),
+	}
+}
+
+func (e ClusterUnavailableError) Error() string {
+	docLink := "https://docs.databricks.com/dev-tools/api/latest/clusters.html#clusterclusterstate"
+	return fmt.Sprintf("%s is not able to transition from %s to %s: %s.%s Please see %s for more details",
+		e.ClusterID, e.State, e.Desired, e.StateMessage, e.hint, docLink)
+}
+
+// spotTerminationCodes are termination reason codes, that indicate lack of
+// spot capacity or loss of spot instances on AWS
+var spotTerminationCodes = map[string]bool{
+	"AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE": true,
+	"SPOT_INSTANCE_TERMINATION":                  true,
+}
+
+// spotCapacityHint returns actionable suggestion, if cluster with spot-only
+// availability was terminated because spot instances could not be acquired
+func spotCapacityHint(ci ClusterInfo) string {
+	if ci.AwsAttributes == nil || ci.AwsAttributes.Availability != AwsAvailabilitySpot {
+		return ""
+	}
+	tr := ci.TerminationReason
+	if tr == nil {
+		return ""
+	}
+	_, hasSpotFault := tr.Parameters["aws_spot_request_fault_code"]
+	if !spotTerminationCodes[tr.Code] && !hasSpotFault {
+		return ""
+	}
+	details := tr.Code
+	for _, param := range []string{"aws_spot_request_status", "aws_spot_request_fault_code"} {
+		if v, ok := tr.Parameters[param]; ok {
+			details = fmt.Sprintf("%s, %s=%s", details, param, v)
+		}
+	}
+	return fmt.Sprintf(" Spot instances could not be acquired (%s). Consider setting "+
+		"aws_attributes.availability to %s, so that on-demand instances are used "+
+		"when spot capacity is not available.", details, AwsAvailabilitySpotWithFallback)
 }
 
 // Terminate terminates a Spark cluster given its ID
@@ -204,12 +352,16 @@ func (a ClustersAPI) Terminate(clusterID string) error {
 // PermanentDelete permanently delete a cluster
 func (a ClustersAPI) PermanentDelete(clusterID string) error {
 	err := a.Terminate(clusterID)
+	if isMissingClusterError(err, clusterID) {
+		log.Printf("[INFO] Cluster %s is already deleted", clusterID)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 	r := ClusterID{ClusterID: clusterID}
 	err = a.client.Post(a.context, "/clusters/permanent-delete", r, nil)
-	if err == nil {
+	if err == nil || isMissingClusterError(err, clusterID) {
 		return nil
 	}
 	if !strings.Contains(err.Error(), "unpin the cluster first") {
@@ -224,6 +376,12 @@ func (a ClustersAPI) PermanentDelete(clusterID string) error {
 	return a.client.Post(a.context, "/clusters/permanent-delete", r, nil)
 }
 
+// isMissingClusterError returns true, if error says that cluster does not exist
+func isMissingClusterError(err error, clusterID string) bool {
+	apiErr, ok := wrapMissingClusterError(err, clusterID).(common.APIError)
+	return ok && apiErr.IsMissing()
+}
+
 // Get retrieves the information for a cluster given its identifier
 func (a ClustersAPI) Get(clusterID string) (ci ClusterInfo, err error) {
 	err = wrapMissingClusterError(a.client.Get(a.context, "/clusters/get",
@@ -330,6 +488,10 @@ func (a ClustersAPI) GetOrCreateRunningCluster(name string, custom ...Cluster) (
 			}
 		}
 	}
+	if len(custom) == 1 {
+		log.Printf("[INFO] Creating custom autoterminating cluster with node type %s", custom[0].NodeTypeID)
+		return a.Create(custom[0])
+	}
 	smallestNodeType := a.GetSmallestNodeType(NodeTypeRequest{
 		LocalDisk: true,
 	})
@@ -349,9 +511,6 @@ func (a ClustersAPI) GetOrCreateRunningCluster(name string, custom ...Cluster) (
 			Availability: "SPOT",
 		}
 	}
-	if len(custom) == 1 {
-		r = custom[0]
-	}
 	return a.Create(r)
 }
 
@@ -2,10 +2,12 @@ package compute
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
@@ -235,6 +237,45 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 	_, err = NewClustersAPI(ctx, client).waitForClusterStatus("abc", ClusterStateRunning)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "abc is not able to transition from UNKNOWN to RUNNING: Something strange is going on.")
+	unavailable, ok := err.(ClusterUnavailableError)
+	require.True(t, ok, err)
+	assert.Equal(t, ClusterState(ClusterStateUnknown), unavailable.State)
+	assert.Equal(t, "Something strange is going on", unavailable.StateMessage)
+}
+
+func TestWaitForClusterStatus_SpotCapacityFailure(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:        ClusterStateTerminated,
+				StateMessage: "Could not launch spot instances",
+				AwsAttributes: &AwsAttributes{
+					Availability: AwsAvailabilitySpot,
+				},
+				TerminationReason: &TerminationReason{
+					Code: "AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE",
+					Type: "CLOUD_FAILURE",
+					Parameters: map[string]string{
+						"aws_spot_request_status":     "capacity-not-available",
+						"aws_spot_request_fault_code": "InsufficientInstanceCapacity",
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).waitForClusterStatus("abc", ClusterStateRunning)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "abc is not able to transition from TERMINATED to RUNNING: "+
+		"Could not launch spot instances. Spot instances could not be acquired "+
+		"(AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE, aws_spot_request_status=capacity-not-available, "+
+		"aws_spot_request_fault_code=InsufficientInstanceCapacity). Consider setting "+
+		"aws_attributes.availability to SPOT_WITH_FALLBACK")
 }
 
 func TestWaitForClusterStatus_NormalRetry(t *testing.T) {
@@ -516,6 +557,66 @@ func TestStartAndGetInfo_StartingError(t *testing.T) {
 	assert.Contains(t, err.Error(), "I am a teapot")
 }
 
+func TestPermanentDelete_AlreadyDeleted(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State: ClusterStateTerminated,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/permanent-delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Cluster abc does not exist",
+			},
+			Status: 404,
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = NewClustersAPI(ctx, client).PermanentDelete("abc")
+	require.NoError(t, err)
+}
+
+func TestPermanentDelete_NotFoundOnTerminate(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Cluster abc does not exist",
+			},
+			Status: 400,
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = NewClustersAPI(ctx, client).PermanentDelete("abc")
+	require.NoError(t, err)
+}
+
 func TestPermanentDelete_Pinned(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -566,6 +667,176 @@ func TestPermanentDelete_Pinned(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCreateCluster_PolicyViolation(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message: "Validation failed for spark_version, the value must be 7.3.x-scala2.12 " +
+					"(is \"6.4.x-scala2.11\"); Validation failed for autotermination_minutes, " +
+					"the value must be less than or equal to 60 (is \"120\")",
+			},
+			Status: 400,
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Create(Cluster{
+		ClusterName:            "abc",
+		SparkVersion:           "6.4.x-scala2.11",
+		AutoterminationMinutes: 120,
+		PolicyID:               "ABCD",
+	})
+	assert.EqualError(t, err, "Cluster definition violates policy ABCD:\n"+
+		" * spark_version: the value must be 7.3.x-scala2.12 (is \"6.4.x-scala2.11\")\n"+
+		" * autotermination_minutes: the value must be less than or equal to 60 (is \"120\")")
+}
+
+func TestCreateCluster_NotPolicyViolation(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Missing required field: spark_version",
+			},
+			Status: 400,
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Create(Cluster{
+		ClusterName: "abc",
+	})
+	assert.EqualError(t, err, "Missing required field: spark_version")
+}
+
+func fastClusterLaunchRetries() func() {
+	minBackoff, maxBackoff := clusterLaunchMinBackoff, clusterLaunchMaxBackoff
+	clusterLaunchMinBackoff = time.Millisecond
+	clusterLaunchMaxBackoff = 2 * time.Millisecond
+	return func() {
+		clusterLaunchMinBackoff, clusterLaunchMaxBackoff = minBackoff, maxBackoff
+	}
+}
+
+func failedToStartFixtures(clusterID, terminationCode string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			Response: ClusterID{
+				ClusterID: clusterID,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: fmt.Sprintf("/api/2.0/clusters/get?cluster_id=%s", clusterID),
+			Response: ClusterInfo{
+				State:        ClusterStateTerminated,
+				StateMessage: "Failed to launch",
+				TerminationReason: &TerminationReason{
+					Code: terminationCode,
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: clusterID,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: fmt.Sprintf("/api/2.0/clusters/get?cluster_id=%s", clusterID),
+			Response: ClusterInfo{
+				State: ClusterStateTerminated,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/permanent-delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: clusterID,
+			},
+		},
+	}
+}
+
+func TestCreateCluster_RetryableLaunchFailure(t *testing.T) {
+	defer fastClusterLaunchRetries()()
+	client, server, err := qa.HttpFixtureClient(t, append(
+		failedToStartFixtures("abc", "CLOUD_PROVIDER_LAUNCH_FAILURE"),
+		qa.HTTPFixture{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			Response: ClusterID{
+				ClusterID: "def",
+			},
+		},
+		qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=def",
+			Response: ClusterInfo{
+				ClusterID: "def",
+				State:     ClusterStateRunning,
+			},
+		}))
+	defer server.Close()
+	require.NoError(t, err)
+	client.ClusterCreateMaxAttempts = 3
+
+	ctx := context.Background()
+	info, err := NewClustersAPI(ctx, client).Create(Cluster{
+		ClusterName: "abc",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "def", info.ClusterID)
+}
+
+func TestCreateCluster_RetryableLaunchFailureNoAttemptsLeft(t *testing.T) {
+	defer fastClusterLaunchRetries()()
+	client, server, err := qa.HttpFixtureClient(t, append(
+		failedToStartFixtures("abc", "CLOUD_PROVIDER_LAUNCH_FAILURE"),
+		failedToStartFixtures("def", "AWS_INSUFFICIENT_INSTANCE_CAPACITY_FAILURE")...))
+	defer server.Close()
+	require.NoError(t, err)
+	client.ClusterCreateMaxAttempts = 2
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Create(Cluster{
+		ClusterName: "abc",
+	})
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed after 2 attempts: def is not able "+
+		"to transition from TERMINATED to RUNNING: Failed to launch."), err.Error())
+}
+
+func TestCreateCluster_TerminalLaunchFailure(t *testing.T) {
+	defer fastClusterLaunchRetries()()
+	client, server, err := qa.HttpFixtureClient(t,
+		failedToStartFixtures("abc", "INSTANCE_POOL_NOT_FOUND"))
+	defer server.Close()
+	require.NoError(t, err)
+	client.ClusterCreateMaxAttempts = 3
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Create(Cluster{
+		ClusterName: "abc",
+	})
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "abc is not able to transition "+
+		"from TERMINATED to RUNNING: Failed to launch."), err.Error())
+}
+
 func TestAccListClustersIntegration(t *testing.T) {
 	cloudEnv := os.Getenv("CLOUD_ENV")
 	if cloudEnv == "" {
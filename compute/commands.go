@@ -2,12 +2,8 @@ package compute
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"html"
 	"log"
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -16,19 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-var (
-	// IPython's output prefixes
-	outRE = regexp.MustCompile(`Out\[[\d\s]+\]:\s`)
-	// HTML tags
-	tagRE = regexp.MustCompile(`<[^>]*>`)
-	// just exception content without exception name
-	exceptionRE = regexp.MustCompile(`.*Exception: (.*)`)
-	// execution errors resulting from http errors are sometimes hidden in these keys
-	executionErrorRE = regexp.MustCompile(`ExecutionError: ([\s\S]*)\n(StatusCode=[0-9]*)\n(StatusDescription=.*)\n`)
-	// usual error message explanation is hidden in this key
-	errorMessageRE = regexp.MustCompile(`ErrorMessage=(.+)\n`)
-)
-
 // NewCommandsAPI creates CommandsAPI instance from provider meta
 func NewCommandsAPI(ctx context.Context, m interface{}) CommandsAPI {
 	return CommandsAPI{
@@ -43,86 +26,79 @@ type CommandsAPI struct {
 	context context.Context
 }
 
+// maxLoggedCommandBytes limits size of the command in logs, as mount commands
+// embed whole Python templates
+const maxLoggedCommandBytes = 1024
+
+func truncateCommand(commandStr string) string {
+	if len(commandStr) <= maxLoggedCommandBytes {
+		return commandStr
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", commandStr[:maxLoggedCommandBytes],
+		len(commandStr)-maxLoggedCommandBytes)
+}
+
 // Execute creates a spark context and executes a command and then closes context
 // Any leading whitespace is trimmed
-func (a CommandsAPI) Execute(clusterID, language, commandStr string) (result string, err error) {
+func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.CommandResults {
 	cluster, err := NewClustersAPI(a.context, a.client).Get(clusterID)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
 	if !cluster.IsRunningOrResizing() {
-		err = fmt.Errorf("Cluster %s has to be running or resizing, but is %s", clusterID, cluster.State)
-		return
+		return errorCommandResults(fmt.Errorf(
+			"Cluster %s has to be running or resizing, but is %s", clusterID, cluster.State))
 	}
 	commandStr = internal.TrimLeadingWhitespace(commandStr)
-	log.Printf("[INFO] Executing %s command on %s:\n%s", language, clusterID, commandStr)
+	log.Printf("[INFO] [%s] Executing %s command on %s:\n%s", common.CorrelationID.GetOrUnknown(a.context),
+		language, clusterID, common.Redact(truncateCommand(commandStr)))
 	context, err := a.createContext(language, clusterID)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
 	err = a.waitForContextReady(context, clusterID)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
 	commandID, err := a.createCommand(context, clusterID, language, commandStr)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
 	err = a.waitForCommandFinished(commandID, context, clusterID)
 	if err != nil {
-		return
+		if a.context.Err() != nil {
+			a.cancelCommand(commandID, context, clusterID)
+		}
+		return errorCommandResults(err)
 	}
 	command, err := a.getCommand(commandID, context, clusterID)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
 	err = a.deleteContext(context, clusterID)
 	if err != nil {
-		return
+		return errorCommandResults(err)
 	}
-	return a.parseCommandResults(command)
-}
-
-func (a CommandsAPI) parseCommandResults(command Command) (result string, err error) {
 	if command.Results == nil {
-		err = fmt.Errorf("Command has no results: %#v", command)
-		return
-	}
-	switch command.Results.ResultType {
-	case "text":
-		result = outRE.ReplaceAllLiteralString(command.Results.Data.(string), "")
-		return
-	case "error":
-		log.Printf("[DEBUG] error caused by command: %s", command.Results.Cause)
-		err = a.getCommandResultErrorMessage(command)
-		return
-	}
-	err = fmt.Errorf("Unknown result type %s: %v", command.Results.ResultType, command.Results.Data)
-	return
-}
-
-func (a CommandsAPI) getCommandResultErrorMessage(command Command) error {
-	summary := tagRE.ReplaceAllLiteralString(command.Results.Summary, "")
-	summary = html.UnescapeString(summary)
-
-	exceptionMatches := exceptionRE.FindStringSubmatch(summary)
-	if len(exceptionMatches) == 2 {
-		summary = strings.ReplaceAll(exceptionMatches[1], "; nested exception is:", "")
-		summary = strings.TrimRight(summary, " ")
-		return errors.New(summary)
+		return errorCommandResults(fmt.Errorf("Command has no results: %#v", command))
 	}
-
-	executionErrorMatches := executionErrorRE.FindStringSubmatch(command.Results.Cause)
-	if len(executionErrorMatches) == 4 {
-		return errors.New(strings.Join(executionErrorMatches[1:], "\n"))
+	command.Results.Status = common.CommandStatusFinished
+	if command.Results.Failed() {
+		command.Results.Status = common.CommandStatusError
+		log.Printf("[DEBUG] [%s] error caused by command: %s",
+			common.CorrelationID.GetOrUnknown(a.context), common.Redact(command.Results.Cause))
 	}
+	return *command.Results
+}
 
-	errorMessageMatches := errorMessageRE.FindStringSubmatch(command.Results.Cause)
-	if len(errorMessageMatches) == 2 {
-		return errors.New(errorMessageMatches[1])
+// errorCommandResults returns failed results for errors, that happened before
+// or after command execution
+func errorCommandResults(err error) common.CommandResults {
+	return common.CommandResults{
+		Status:     common.CommandStatusError,
+		ResultType: "error",
+		Summary:    err.Error(),
 	}
-
-	return errors.New(summary)
 }
 
 type genericCommandRequest struct {
@@ -154,6 +130,45 @@ func (a CommandsAPI) getCommand(commandID, contextID, clusterID string) (Command
 	return commandResp, err
 }
 
+// commandCancelTimeout bounds cleanup of commands, that outlived their context
+const commandCancelTimeout = 1 * time.Minute
+
+// detachedContext keeps values of the parent context, like correlation id,
+// but is never cancelled with it
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{}                   { return nil }
+func (detachedContext) Err() error                              { return nil }
+func (c detachedContext) Value(key interface{}) interface{}     { return c.parent.Value(key) }
+
+// cancelCommand stops the command and destroys its execution context, once the
+// operation is cancelled or timed out, so that commands don't keep running on
+// the cluster. Cleanup errors are only logged, as the command has failed anyway.
+func (a CommandsAPI) cancelCommand(commandID, contextID, clusterID string) {
+	ctx, cancel := context.WithTimeout(detachedContext{a.context}, commandCancelTimeout)
+	defer cancel()
+	cleanup := CommandsAPI{
+		client:  a.client,
+		context: ctx,
+	}
+	log.Printf("[INFO] [%s] Cancelling command %s on %s: %s",
+		common.CorrelationID.GetOrUnknown(ctx), commandID, clusterID, a.context.Err())
+	err := a.client.OldAPI(ctx, "POST", "/commands/cancel", genericCommandRequest{
+		CommandID: commandID,
+		ContextID: contextID,
+		ClusterID: clusterID,
+	}, nil)
+	if err != nil {
+		log.Printf("[WARN] Cannot cancel command %s: %s", commandID, err)
+	}
+	if err = cleanup.deleteContext(contextID, clusterID); err != nil {
+		log.Printf("[WARN] Cannot destroy execution context %s: %s", contextID, err)
+	}
+}
+
 func (a CommandsAPI) deleteContext(contextID, clusterID string) error {
 	return a.client.OldAPI(a.context, "POST", "/contexts/destroy", genericCommandRequest{
 		ContextID: contextID,
@@ -180,7 +195,7 @@ func (a CommandsAPI) createContext(language, clusterID string) (string, error) {
 }
 
 func (a CommandsAPI) waitForCommandFinished(commandID, contextID, clusterID string) error {
-	return resource.RetryContext(a.context, 10*time.Minute, func() *resource.RetryError {
+	err := resource.RetryContext(a.context, 10*time.Minute, func() *resource.RetryError {
 		commandInfo, err := a.getCommand(commandID, contextID, clusterID)
 		if err != nil {
 			return resource.NonRetryableError(err)
@@ -194,10 +209,11 @@ func (a CommandsAPI) waitForCommandFinished(commandID, contextID, clusterID stri
 		log.Printf("[DEBUG] Command is in %s state", commandInfo.Status)
 		return resource.RetryableError(fmt.Errorf(commandInfo.Status))
 	})
+	return withDeadlineError(a.context, err)
 }
 
 func (a CommandsAPI) waitForContextReady(contextID, clusterID string) error {
-	return resource.RetryContext(a.context, 10*time.Minute, func() *resource.RetryError {
+	err := resource.RetryContext(a.context, 10*time.Minute, func() *resource.RetryError {
 		status, err := a.getContext(contextID, clusterID)
 		if err != nil {
 			return resource.NonRetryableError(err)
@@ -210,4 +226,5 @@ func (a CommandsAPI) waitForContextReady(contextID, clusterID string) error {
 		}
 		return resource.RetryableError(fmt.Errorf(status))
 	})
+	return withDeadlineError(a.context, err)
 }
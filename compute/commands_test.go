@@ -2,8 +2,11 @@ package compute
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -83,7 +86,7 @@ func commonFixtureWithStatusResponse(response Command) []qa.HTTPFixture {
 func TestCommandWithExecutionError(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, commonFixtureWithStatusResponse(Command{
 		Status: "Finished",
-		Results: &CommandResults{
+		Results: &common.CommandResults{
 			ResultType: "error",
 			Cause: `
 ---
@@ -99,16 +102,62 @@ StatusDescription=BadRequest
 	ctx := context.Background()
 	commands := NewCommandsAPI(ctx, client)
 
-	_, err = commands.Execute("abc", "python", `print("done")`)
+	result := commands.Execute("abc", "python", `print("done")`)
+	assert.True(t, result.Failed())
 	assert.Equal(t, `An error occurred
 StatusCode=400
-StatusDescription=BadRequest`, err.Error())
+StatusDescription=BadRequest`, result.Error())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCommandCancelledWithContext(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, append(commonFixtureWithStatusResponse(Command{
+		Status: "Running",
+	}), qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/1.2/commands/cancel",
+		ExpectedRequest: genericCommandRequest{
+			CommandID: "234",
+			ClusterID: "abc",
+			ContextID: "123",
+		},
+	}))
+	defer server.Close()
+	require.NoError(t, err)
+	var mutex sync.Mutex
+	var calls []string
+	client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		calls = append(calls, r.URL.Path)
+		mutex.Unlock()
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	require.NoError(t, client.Configure())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	result := NewCommandsAPI(ctx, client).Execute("abc", "python", `print("done")`)
+	assert.True(t, result.Failed())
+	assert.Contains(t, result.Error(), "context deadline exceeded")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.True(t, len(calls) >= 2, calls)
+	assert.Equal(t, []string{
+		"/api/1.2/commands/cancel",
+		"/api/1.2/contexts/destroy",
+	}, calls[len(calls)-2:])
 }
 
 func TestCommandWithEmptyErrorMessageUsesSummary(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, commonFixtureWithStatusResponse(Command{
 		Status: "Finished",
-		Results: &CommandResults{
+		Results: &common.CommandResults{
 			ResultType: "error",
 			Cause: `
 ---
@@ -125,14 +174,15 @@ ErrorMessage=
 	ctx := context.Background()
 	commands := NewCommandsAPI(ctx, client)
 
-	_, err = commands.Execute("abc", "python", `print("done")`)
-	assert.Equal(t, "Proper error", err.Error())
+	result := commands.Execute("abc", "python", `print("done")`)
+	assert.True(t, result.Failed())
+	assert.Equal(t, "Proper error", result.Error())
 }
 
 func TestCommandWithErrorMessage(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, commonFixtureWithStatusResponse(Command{
 		Status: "Finished",
-		Results: &CommandResults{
+		Results: &common.CommandResults{
 			ResultType: "error",
 			Cause: `
 ---
@@ -147,14 +197,15 @@ ErrorMessage=An error occurred
 	ctx := context.Background()
 	commands := NewCommandsAPI(ctx, client)
 
-	_, err = commands.Execute("abc", "python", `print("done")`)
-	assert.Equal(t, "An error occurred", err.Error())
+	result := commands.Execute("abc", "python", `print("done")`)
+	assert.True(t, result.Failed())
+	assert.Equal(t, "An error occurred", result.Error())
 }
 
 func TestCommandWithExceptionMessage(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, commonFixtureWithStatusResponse(Command{
 		Status: "Finished",
-		Results: &CommandResults{
+		Results: &common.CommandResults{
 			ResultType: "error",
 			Summary:    "Exception: An error occurred",
 		},
@@ -164,14 +215,15 @@ func TestCommandWithExceptionMessage(t *testing.T) {
 	ctx := context.Background()
 	commands := NewCommandsAPI(ctx, client)
 
-	_, err = commands.Execute("abc", "python", `print("done")`)
-	assert.Equal(t, "An error occurred", err.Error())
+	result := commands.Execute("abc", "python", `print("done")`)
+	assert.True(t, result.Failed())
+	assert.Equal(t, "An error occurred", result.Error())
 }
 
 func TestSomeCommands(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, commonFixtureWithStatusResponse(Command{
 		Status: "Finished",
-		Results: &CommandResults{
+		Results: &common.CommandResults{
 			ResultType: "text",
 			Data:       "done",
 		},
@@ -181,9 +233,9 @@ func TestSomeCommands(t *testing.T) {
 	ctx := context.Background()
 	commands := NewCommandsAPI(ctx, client)
 
-	result, err := commands.Execute("abc", "python", `print("done")`)
-	require.NoError(t, err)
-	assert.Equal(t, "done", result)
+	result := commands.Execute("abc", "python", `print("done")`)
+	require.False(t, result.Failed(), result.Error())
+	assert.Equal(t, "done", result.Text())
 }
 
 func TestAccContext(t *testing.T) {
@@ -197,32 +249,32 @@ func TestAccContext(t *testing.T) {
 	ctx := context.Background()
 	c := NewCommandsAPI(ctx, client)
 
-	result, err := c.Execute(clusterID, "python", `print('hello world')`)
-	require.NoError(t, err)
-	assert.Equal(t, "hello world", result)
+	result := c.Execute(clusterID, "python", `print('hello world')`)
+	require.False(t, result.Failed(), result.Error())
+	assert.Equal(t, "hello world", result.Text())
 
 	// exceptions are regexed away for readability
-	result, err = c.Execute(clusterID, "python", `raise Exception("Not Found")`)
-	qa.AssertErrorStartsWith(t, err, "Not Found")
-	assert.Equal(t, "", result)
+	result = c.Execute(clusterID, "python", `raise Exception("Not Found")`)
+	qa.AssertErrorStartsWith(t, result.Err(), "Not Found")
+	assert.Equal(t, "", result.Text())
 
 	// but errors are not
-	result, err = c.Execute(clusterID, "python", `raise KeyError("foo")`)
-	qa.AssertErrorStartsWith(t, err, "KeyError: 'foo'")
-	assert.Equal(t, "", result)
+	result = c.Execute(clusterID, "python", `raise KeyError("foo")`)
+	qa.AssertErrorStartsWith(t, result.Err(), "KeyError: 'foo'")
+	assert.Equal(t, "", result.Text())
 
 	// so it is more clear to read and debug
-	result, err = c.Execute(clusterID, "python", `return 'hello world'`)
-	qa.AssertErrorStartsWith(t, err, "SyntaxError: 'return' outside function")
-	assert.Equal(t, "", result)
+	result = c.Execute(clusterID, "python", `return 'hello world'`)
+	qa.AssertErrorStartsWith(t, result.Err(), "SyntaxError: 'return' outside function")
+	assert.Equal(t, "", result.Text())
 
-	result, err = c.Execute(clusterID, "python", `"Hello World!"`)
-	assert.NoError(t, err)
-	assert.Equal(t, "'Hello World!'", result)
+	result = c.Execute(clusterID, "python", `"Hello World!"`)
+	assert.False(t, result.Failed())
+	assert.Equal(t, "'Hello World!'", result.Text())
 
-	result, err = c.Execute(clusterID, "python", `
+	result = c.Execute(clusterID, "python", `
 		print("Hello World!")
 		dbutils.notebook.exit("success")`)
-	assert.NoError(t, err)
-	assert.Equal(t, "success", result)
+	assert.False(t, result.Failed())
+	assert.Equal(t, "success", result.Text())
 }
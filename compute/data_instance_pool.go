@@ -0,0 +1,98 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findInstancePool returns pool with stats either by its ID or by its name
+func findInstancePool(instancePoolsAPI InstancePoolsAPI, id, name string) (ip InstancePoolAndStats, err error) {
+	if id != "" {
+		return instancePoolsAPI.Get(id)
+	}
+	if name == "" {
+		err = fmt.Errorf("Either instance_pool_id or name must be specified")
+		return
+	}
+	pools, err := instancePoolsAPI.List()
+	if err != nil {
+		return
+	}
+	for _, pool := range pools.InstancePools {
+		if pool.InstancePoolName == name {
+			return pool, nil
+		}
+	}
+	err = fmt.Errorf("Instance pool %s does not exist", name)
+	return
+}
+
+// DataSourceInstancePool returns instance pool configuration along with its current usage stats
+func DataSourceInstancePool() *schema.Resource {
+	computedInt := func() *schema.Schema {
+		return &schema.Schema{Type: schema.TypeInt, Computed: true}
+	}
+	computedString := func() *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Computed: true}
+	}
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			pool, err := findInstancePool(NewInstancePoolsAPI(ctx, m),
+				d.Get("instance_pool_id").(string), d.Get("name").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			stats := InstancePoolStats{}
+			if pool.Stats != nil {
+				stats = *pool.Stats
+			}
+			d.SetId(pool.InstancePoolID)
+			for k, v := range map[string]interface{}{
+				"instance_pool_id":                      pool.InstancePoolID,
+				"name":                                  pool.InstancePoolName,
+				"node_type_id":                          pool.NodeTypeID,
+				"min_idle_instances":                    pool.MinIdleInstances,
+				"max_capacity":                          pool.MaxCapacity,
+				"idle_instance_autotermination_minutes": pool.IdleInstanceAutoTerminationMinutes,
+				"state":                                 pool.State,
+				"used_count":                            stats.UsedCount,
+				"idle_count":                            stats.IdleCount,
+				"pending_used_count":                    stats.PendingUsedCount,
+				"pending_idle_count":                    stats.PendingIdleCount,
+				"pending_count":                         stats.PendingUsedCount + stats.PendingIdleCount,
+			} {
+				if err = d.Set(k, v); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"instance_pool_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"instance_pool_id"},
+			},
+			"node_type_id":                          computedString(),
+			"min_idle_instances":                    computedInt(),
+			"max_capacity":                          computedInt(),
+			"idle_instance_autotermination_minutes": computedInt(),
+			"state":                                 computedString(),
+			"used_count":                            computedInt(),
+			"idle_count":                            computedInt(),
+			"pending_used_count":                    computedInt(),
+			"pending_idle_count":                    computedInt(),
+			"pending_count":                         computedInt(),
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceInstancePool(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					MinIdleInstances:                   2,
+					MaxCapacity:                        10,
+					IdleInstanceAutoTerminationMinutes: 15,
+					State:                              "ACTIVE",
+					Stats: &InstancePoolStats{
+						UsedCount:        3,
+						IdleCount:        2,
+						PendingUsedCount: 1,
+						PendingIdleCount: 1,
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceInstancePool(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"instance_pool_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Shared Pool", d.Get("name"))
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+	assert.Equal(t, 10, d.Get("max_capacity"))
+	assert.Equal(t, 3, d.Get("used_count"))
+	assert.Equal(t, 2, d.Get("idle_count"))
+	assert.Equal(t, 2, d.Get("pending_count"))
+}
+
+func TestDataSourceInstancePool_ByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: InstancePoolList{
+					InstancePools: []InstancePoolAndStats{
+						{
+							InstancePoolID:   "bcd",
+							InstancePoolName: "Other Pool",
+						},
+						{
+							InstancePoolID:   "abc",
+							InstancePoolName: "Shared Pool",
+							Stats: &InstancePoolStats{
+								IdleCount: 4,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceInstancePool(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"name": "Shared Pool",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 4, d.Get("idle_count"))
+	assert.Equal(t, 0, d.Get("used_count"))
+}
+
+func TestDataSourceInstancePool_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: InstancePoolList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceInstancePool(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"name": "Shared Pool",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Instance pool Shared Pool does not exist")
+}
@@ -109,3 +109,47 @@ func TestNodeType(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Random_03", d.Id())
 }
+
+func TestNodeTypeCategory(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID: "Random_05",
+							MemoryMB:   1024,
+							NumCores:   4,
+							Category:   "General purpose",
+						},
+						{
+							NodeTypeID: "Random_01",
+							MemoryMB:   8192,
+							NumCores:   8,
+							Category:   "Memory optimized",
+						},
+						{
+							NodeTypeID: "Random_02",
+							MemoryMB:   4096,
+							NumCores:   8,
+							Category:   "Memory optimized",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"category":  "Memory optimized",
+			"min_cores": 8,
+		},
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "Random_02", d.Id())
+}
@@ -3,6 +3,8 @@ package compute
 import (
 	"fmt"
 	"sort"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
 )
 
 // AutoScale is a struct the describes auto scaling for clusters
@@ -145,6 +147,11 @@ type StorageInfo struct {
 	S3   *S3StorageInfo   `json:"s3,omitempty" tf:"group:storage"`
 }
 
+// GcpAttributes encapsulates GCP specific attributes for GCP based clusters
+type GcpAttributes struct {
+	GoogleServiceAccount string `json:"google_service_account,omitempty"`
+}
+
 // SparkNodeAwsAttributes is the struct that determines if the node is a spot instance or not
 type SparkNodeAwsAttributes struct {
 	IsSpot bool `json:"is_spot,omitempty"`
@@ -238,6 +245,7 @@ type Cluster struct {
 	InstancePoolID         string         `json:"instance_pool_id,omitempty" tf:"group:node_type"`
 	PolicyID               string         `json:"policy_id,omitempty"`
 	AwsAttributes          *AwsAttributes `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id"`
+	GcpAttributes          *GcpAttributes `json:"gcp_attributes,omitempty"`
 	AutoterminationMinutes int32          `json:"autotermination_minutes,omitempty"`
 
 	SparkConf    map[string]string `json:"spark_conf,omitempty"`
@@ -272,6 +280,7 @@ type ClusterInfo struct {
 	SparkVersion              string             `json:"spark_version"`
 	SparkConf                 map[string]string  `json:"spark_conf,omitempty"`
 	AwsAttributes             *AwsAttributes     `json:"aws_attributes,omitempty"`
+	GcpAttributes             *GcpAttributes     `json:"gcp_attributes,omitempty"`
 	NodeTypeID                string             `json:"node_type_id,omitempty"`
 	DriverNodeTypeID          string             `json:"driver_node_type_id,omitempty"`
 	SSHPublicKeys             []string           `json:"ssh_public_keys,omitempty"`
@@ -324,22 +333,11 @@ type ClusterPolicyCreate struct {
 	Definition string `json:"definition"`
 }
 
-// CommandResults is the out put when the command finishes in API 1.2
-type CommandResults struct {
-	ResultType   string      `json:"resultType,omitempty"`
-	Summary      string      `json:"summary,omitempty"`
-	Cause        string      `json:"cause,omitempty"`
-	Data         interface{} `json:"data,omitempty"`
-	Schema       interface{} `json:"schema,omitempty"`
-	Truncated    bool        `json:"truncated,omitempty"`
-	IsJSONSchema bool        `json:"isJsonSchema,omitempty"`
-}
-
 // Command is the struct that contains what the 1.2 api returns for the commands api
 type Command struct {
-	ID      string          `json:"id,omitempty"`
-	Status  string          `json:"status,omitempty"`
-	Results *CommandResults `json:"results,omitempty"`
+	ID      string                 `json:"id,omitempty"`
+	Status  string                 `json:"status,omitempty"`
+	Results *common.CommandResults `json:"results,omitempty"`
 }
 
 // InstancePoolAwsAttributes contains aws attributes for AWS Databricks deployments for instance pools
@@ -469,6 +467,11 @@ type SparkSubmitTask struct {
 	Parameters []string `json:"parameters,omitempty"`
 }
 
+// RunJobTask contains the information for jobs, that trigger other jobs
+type RunJobTask struct {
+	JobID int64 `json:"job_id"`
+}
+
 // JobEmailNotifications contains the information for email notifications after job completion
 type JobEmailNotifications struct {
 	OnStart               []string `json:"on_start,omitempty"`
@@ -477,6 +480,17 @@ type JobEmailNotifications struct {
 	NoAlertForSkippedRuns bool     `json:"no_alert_for_skipped_runs,omitempty"`
 }
 
+// JobNotificationSettings control alerts sent for runs of a job
+type JobNotificationSettings struct {
+	NoAlertForSkippedRuns  bool `json:"no_alert_for_skipped_runs,omitempty"`
+	NoAlertForCanceledRuns bool `json:"no_alert_for_canceled_runs,omitempty"`
+}
+
+// JobQueue contains the information for queueing runs of a job once concurrency limit is reached
+type JobQueue struct {
+	Enabled bool `json:"enabled"`
+}
+
 // CronSchedule contains the information for the quartz cron expression
 type CronSchedule struct {
 	QuartzCronExpression string `json:"quartz_cron_expression"`
@@ -495,6 +509,7 @@ type JobSettings struct {
 	SparkJarTask    *SparkJarTask    `json:"spark_jar_task,omitempty" tf:"group:task_type"`
 	SparkPythonTask *SparkPythonTask `json:"spark_python_task,omitempty" tf:"group:task_type"`
 	SparkSubmitTask *SparkSubmitTask `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	RunJobTask      *RunJobTask      `json:"run_job_task,omitempty" tf:"group:task_type"`
 
 	Libraries              []Library     `json:"libraries,omitempty" tf:"slice_set,alias:library"`
 	TimeoutSeconds         int32         `json:"timeout_seconds,omitempty"`
@@ -504,7 +519,9 @@ type JobSettings struct {
 	Schedule               *CronSchedule `json:"schedule,omitempty"`
 	MaxConcurrentRuns      int32         `json:"max_concurrent_runs,omitempty"`
 
-	EmailNotifications *JobEmailNotifications `json:"email_notifications,omitempty"`
+	EmailNotifications   *JobEmailNotifications   `json:"email_notifications,omitempty"`
+	NotificationSettings *JobNotificationSettings `json:"notification_settings,omitempty"`
+	Queue                *JobQueue                `json:"queue,omitempty"`
 }
 
 // JobList ...
@@ -110,6 +110,48 @@ func validateClusterDefinition(cluster Cluster) error {
 	return fmt.Errorf("NumWorkers could be 0 only for SingleNode clusters. See https://docs.databricks.com/clusters/single-node.html for more details")
 }
 
+// secretKeys is the response of /secrets/list. Secrets API is called directly,
+// because access package depends on compute package.
+type secretKeys struct {
+	Secrets []struct {
+		Key string `json:"key"`
+	} `json:"secrets,omitempty"`
+}
+
+// validateSecretReference makes sure that referenced secret exists, if value is a secret reference
+func validateSecretReference(ctx context.Context, c *common.DatabricksClient, attr, value string) error {
+	scope, key, ok := common.ClusterSecretReference.Parse(value)
+	if !ok {
+		return nil
+	}
+	var secrets secretKeys
+	err := c.Get(ctx, "/secrets/list", map[string]string{
+		"scope": scope,
+	}, &secrets)
+	if err != nil {
+		return fmt.Errorf("Cannot verify %s secret: %v", attr, err)
+	}
+	for _, secret := range secrets.Secrets {
+		if secret.Key == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s refers to a missing secret %s in %s scope", attr, key, scope)
+}
+
+// validateDockerBasicAuth makes sure that registry credentials, sourced from secrets, exist
+func validateDockerBasicAuth(ctx context.Context, c *common.DatabricksClient, cluster Cluster) error {
+	if cluster.DockerImage == nil || cluster.DockerImage.BasicAuth == nil {
+		return nil
+	}
+	basicAuth := cluster.DockerImage.BasicAuth
+	err := validateSecretReference(ctx, c, "docker_image.basic_auth.username", basicAuth.Username)
+	if err != nil {
+		return err
+	}
+	return validateSecretReference(ctx, c, "docker_image.basic_auth.password", basicAuth.Password)
+}
+
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	var cluster Cluster
 	clusters := NewClustersAPI(ctx, c)
@@ -120,6 +162,9 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err = validateClusterDefinition(cluster); err != nil {
 		return err
 	}
+	if err = validateDockerBasicAuth(ctx, c, cluster); err != nil {
+		return err
+	}
 	modifyClusterRequest(&cluster)
 	clusterInfo, err := clusters.Create(cluster)
 	if err != nil {
@@ -174,6 +219,7 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err != nil {
 		return err
 	}
+	clusterInfo.SparkEnvVars = reconcileSparkEnvVars(d, clusterInfo.SparkEnvVars)
 	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
@@ -189,6 +235,27 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	return common.StructToData(libList, clusterSchema, d)
 }
 
+// platformSparkEnvVars are added by Databricks to spark_env_vars of clusters
+var platformSparkEnvVars = map[string]bool{
+	"PYSPARK_PYTHON": true,
+}
+
+// reconcileSparkEnvVars skips well-known environment variables injected by the
+// platform, unless they are specified by user, so that they are not reported as
+// drift. All other remote variables are kept, so that out-of-band changes are detected.
+func reconcileSparkEnvVars(d *schema.ResourceData, remote map[string]string) map[string]string {
+	userVars := d.Get("spark_env_vars").(map[string]interface{})
+	reconciled := map[string]string{}
+	for k, v := range remote {
+		if _, ok := userVars[k]; !ok && platformSparkEnvVars[k] {
+			log.Printf("[DEBUG] Ignoring spark_env_vars.%s added by platform", k)
+			continue
+		}
+		reconciled[k] = v
+	}
+	return reconciled
+}
+
 func waitForLibrariesInstalled(
 	libraries LibrariesAPI, clusterInfo ClusterInfo) (result *ClusterLibraryStatuses, err error) {
 	err = resource.RetryContext(libraries.context, 30*time.Minute, func() *resource.RetryError {
@@ -247,6 +314,11 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err != nil {
 			return err
 		}
+		if d.HasChange("docker_image") {
+			if err = validateDockerBasicAuth(ctx, c, cluster); err != nil {
+				return err
+			}
+		}
 		modifyClusterRequest(&cluster)
 		clusterInfo, err = clusters.Edit(cluster)
 		if err != nil {
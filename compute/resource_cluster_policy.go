@@ -2,6 +2,11 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -55,7 +60,16 @@ func (a ClusterPoliciesAPI) Delete(policyID string) error {
 	return a.client.Post(a.context, "/policies/clusters/delete", policyIDWrapper{policyID}, nil)
 }
 
-func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
+// policyLibrariesAttribute is the element of policy definition with libraries,
+// that are enforced on clusters governed by the policy
+const policyLibrariesAttribute = "libraries"
+
+type policyLibrariesElement struct {
+	Type  string    `json:"type"`
+	Value []Library `json:"value"`
+}
+
+func parsePolicyFromData(d *schema.ResourceData, s map[string]*schema.Schema) (*ClusterPolicy, error) {
 	clusterPolicy := new(ClusterPolicy)
 	clusterPolicy.PolicyID = d.Id()
 	if name, ok := d.GetOk("name"); ok {
@@ -64,34 +78,198 @@ func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
 	if data, ok := d.GetOk("definition"); ok {
 		clusterPolicy.Definition = data.(string)
 	}
+	var libraryList ClusterLibraryList
+	if err := common.DataToStructPointer(d, s, &libraryList); err != nil {
+		return nil, err
+	}
+	if len(libraryList.Libraries) == 0 {
+		return clusterPolicy, nil
+	}
+	definition := map[string]json.RawMessage{}
+	if clusterPolicy.Definition != "" {
+		if err := json.Unmarshal([]byte(clusterPolicy.Definition), &definition); err != nil {
+			return nil, err
+		}
+	}
+	libraries, err := json.Marshal(policyLibrariesElement{
+		Type:  "fixed",
+		Value: libraryList.Libraries,
+	})
+	if err != nil {
+		return nil, err
+	}
+	definition[policyLibrariesAttribute] = libraries
+	serialized, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+	clusterPolicy.Definition = string(serialized)
 	return clusterPolicy, nil
 }
 
+// extractPolicyLibraries removes enforced libraries from policy definition,
+// so that they are reconciled with library blocks and not with the definition
+func extractPolicyLibraries(clusterPolicy *ClusterPolicy) (libraryList ClusterLibraryList, err error) {
+	definition := map[string]json.RawMessage{}
+	if err = json.Unmarshal([]byte(clusterPolicy.Definition), &definition); err != nil {
+		// definition is left as is, there's nothing to extract from
+		return libraryList, nil
+	}
+	raw, ok := definition[policyLibrariesAttribute]
+	if !ok {
+		return
+	}
+	var element policyLibrariesElement
+	if err = json.Unmarshal(raw, &element); err != nil {
+		return libraryList, fmt.Errorf("Cannot parse enforced libraries: %v", err)
+	}
+	libraryList.Libraries = element.Value
+	delete(definition, policyLibrariesAttribute)
+	if len(definition) == 0 {
+		clusterPolicy.Definition = ""
+		return
+	}
+	serialized, err := json.Marshal(definition)
+	if err != nil {
+		return
+	}
+	clusterPolicy.Definition = string(serialized)
+	return
+}
+
+// policyRequiredFields lists supported policy element types with fields,
+// that each of them requires
+var policyRequiredFields = map[string][]string{
+	"fixed":     {"value"},
+	"forbidden": {},
+	"allowlist": {"values"},
+	"blocklist": {"values"},
+	"regex":     {"pattern"},
+	"range":     {},
+	"unlimited": {},
+}
+
+// policyAttributes lists top-level cluster attributes, that policy elements can refer to.
+// Nested attributes and map keys follow after a dot, e.g. `spark_conf.spark.foo`, while
+// `dbus_per_hour` and `cluster_type` are virtual attributes, that exist only in policies.
+var policyAttributes = map[string]bool{
+	"autoscale":                    true,
+	"autotermination_minutes":      true,
+	"aws_attributes":               true,
+	"azure_attributes":             true,
+	"cluster_log_conf":             true,
+	"cluster_name":                 true,
+	"cluster_type":                 true,
+	"custom_tags":                  true,
+	"dbus_per_hour":                true,
+	"docker_image":                 true,
+	"driver_instance_pool_id":      true,
+	"driver_node_type_id":          true,
+	"enable_elastic_disk":          true,
+	"enable_local_disk_encryption": true,
+	"gcp_attributes":               true,
+	"init_scripts":                 true,
+	"instance_pool_id":             true,
+	"node_type_id":                 true,
+	"num_workers":                  true,
+	"single_user_name":             true,
+	"spark_conf":                   true,
+	"spark_env_vars":               true,
+	"spark_version":                true,
+	"ssh_public_keys":              true,
+}
+
+// validatePolicyDefinition checks that definition is a JSON object, where every
+// attribute is supported and has an element of supported type with all required fields
+func validatePolicyDefinition(i interface{}, k string) (_ []string, errs []error) {
+	var definition map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(i.(string)), &definition); err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a valid policy definition: %v", k, err))
+		return
+	}
+	attributes := []string{}
+	for attribute := range definition {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	for _, attribute := range attributes {
+		element := definition[attribute]
+		if attribute == "" {
+			errs = append(errs, fmt.Errorf("%s has policy element without attribute", k))
+			continue
+		}
+		if attribute == policyLibrariesAttribute {
+			// read always moves enforced libraries to library blocks
+			errs = append(errs, fmt.Errorf("%s cannot have %s element, use library blocks instead",
+				k, policyLibrariesAttribute))
+			continue
+		}
+		if !policyAttributes[strings.SplitN(attribute, ".", 2)[0]] {
+			errs = append(errs, fmt.Errorf("%s has unsupported policy attribute %s", k, attribute))
+			continue
+		}
+		policyType, _ := element["type"].(string)
+		required, ok := policyRequiredFields[policyType]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s has unsupported policy type %#v for %s",
+				k, policyType, attribute))
+			continue
+		}
+		for _, field := range required {
+			if _, ok := element[field]; !ok {
+				errs = append(errs, fmt.Errorf("%s requires %s for %s policy of %s",
+					k, field, policyType, attribute))
+			}
+		}
+	}
+	return
+}
+
+// suppressEquivalentPolicyDefinition ignores formatting and order of keys, as definition
+// is serialized again, when enforced libraries are extracted from it during read
+func suppressEquivalentPolicyDefinition(k, old, new string, d *schema.ResourceData) bool {
+	var oldDefinition, newDefinition interface{}
+	if err := json.Unmarshal([]byte(old), &oldDefinition); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newDefinition); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldDefinition, newDefinition)
+}
+
 // ResourceClusterPolicy ...
 func ResourceClusterPolicy() *schema.Resource {
-	return common.Resource{
-		Schema: map[string]*schema.Schema{
-			"policy_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				Description: "Cluster policy name. This must be unique.\n" +
-					"Length must be between 1 and 100 characters.",
-				ValidateFunc: validation.StringLenBetween(1, 100),
-			},
-			"definition": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Description: "Policy definition JSON document expressed in\n" +
-					"Databricks Policy Definition Language.",
-				ValidateFunc: validation.StringIsJSON,
-			},
+	s := map[string]*schema.Schema{
+		"policy_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			Description: "Cluster policy name. This must be unique.\n" +
+				"Length must be between 1 and 100 characters.",
+			ValidateFunc: validation.StringLenBetween(1, 100),
 		},
+		"definition": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "Policy definition JSON document expressed in\n" +
+				"Databricks Policy Definition Language.",
+			ValidateFunc:     validatePolicyDefinition,
+			DiffSuppressFunc: suppressEquivalentPolicyDefinition,
+		},
+	}
+	// adds `library` configuration blocks with libraries enforced by the policy
+	s["library"] = common.StructToSchema(ClusterLibraryList{},
+		func(ss map[string]*schema.Schema) map[string]*schema.Schema {
+			return ss
+		})["library"]
+	return common.Resource{
+		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			clusterPolicy, err := parsePolicyFromData(d)
+			clusterPolicy, err := parsePolicyFromData(d, s)
 			if err != nil {
 				return err
 			}
@@ -106,6 +284,13 @@ func ResourceClusterPolicy() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			libraryList, err := extractPolicyLibraries(&clusterPolicy)
+			if err != nil {
+				return err
+			}
+			if err = common.StructToData(libraryList, s, d); err != nil {
+				return err
+			}
 			if err = d.Set("name", clusterPolicy.Name); err != nil {
 				return err
 			}
@@ -118,7 +303,7 @@ func ResourceClusterPolicy() *schema.Resource {
 			return nil
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			clusterPolicy, err := parsePolicyFromData(d)
+			clusterPolicy, err := parsePolicyFromData(d, s)
 			if err != nil {
 				return err
 			}
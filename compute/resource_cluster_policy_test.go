@@ -1,12 +1,15 @@
 package compute
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterPolicyRead(t *testing.T) {
@@ -236,3 +239,250 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceClusterPolicyCreate_ValidDefinition(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				Response: policyIDWrapper{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Dummy",
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		State: map[string]interface{}{
+			"definition": `{
+				"spark_version": {"type": "regex", "pattern": "^7\\..*"},
+				"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+				"autotermination_minutes": {"type": "range", "maxValue": 120},
+				"spark_conf.spark.databricks.io.cache.enabled": {"type": "fixed", "value": "true"},
+				"instance_pool_id": {"type": "forbidden"}
+			}`,
+			"name": "Dummy",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestResourceClusterPolicyCreate_MalformedDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]interface{}{
+			"definition": `{"spark_conf.foo": {"type": "fixed", "value": "bar"}`,
+			"name":       "Dummy",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition is not "+
+		"a valid policy definition: unexpected end of JSON input")
+}
+
+func TestResourceClusterPolicyCreate_UnknownPolicyType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]interface{}{
+			"definition": `{"spark_conf.foo": {"type": "sometimes", "value": "bar"}}`,
+			"name":       "Dummy",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition has "+
+		"unsupported policy type sometimes for spark_conf.foo")
+}
+
+func TestResourceClusterPolicyCreate_UnknownPolicyAttribute(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]interface{}{
+			"definition": `{"spark_confs.foo": {"type": "fixed", "value": "bar"}}`,
+			"name":       "Dummy",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition has "+
+		"unsupported policy attribute spark_confs.foo")
+}
+
+func TestResourceClusterPolicyCreate_MissingRequiredField(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]interface{}{
+			"definition": `{"node_type_id": {"type": "allowlist"}}`,
+			"name":       "Dummy",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition requires "+
+		"values for allowlist policy of node_type_id")
+}
+
+func TestResourceClusterPolicyCreate_Libraries(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: ClusterPolicy{
+					Name: "Dummy",
+					Definition: `{"libraries":{"type":"fixed","value":[{"maven":` +
+						`{"coordinates":"com.example:lib:1.0.0"}}]},` +
+						`"spark_conf.foo":{"type":"fixed","value":"bar"}}`,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Dummy",
+					Definition: `{"libraries": {"type": "fixed", "value": [{"maven": ` +
+						`{"coordinates": "com.example:lib:1.0.0"}}]}, ` +
+						`"spark_conf.foo": {"type": "fixed", "value": "bar"}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		definition = "{\"spark_conf.foo\":{\"type\":\"fixed\",\"value\":\"bar\"}}"
+		library {
+			maven {
+				coordinates = "com.example:lib:1.0.0"
+			}
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, `{"spark_conf.foo":{"type":"fixed","value":"bar"}}`, d.Get("definition"))
+	assert.Equal(t, 1, d.Get("library.#"))
+}
+
+func TestResourceClusterPolicyRead_Libraries(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Dummy",
+					Definition: `{"libraries": {"type": "fixed", "value": [` +
+						`{"pypi": {"package": "requests"}}]}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.Get("definition"))
+	assert.Equal(t, 1, d.Get("library.#"))
+	assert.Equal(t, "requests", d.Get("library.754562683.pypi.0.package"))
+}
+
+func TestResourceClusterPolicyCreate_LibrariesInDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		definition = "{\"libraries\":{\"type\":\"fixed\",\"value\":[]}}"
+		library {
+			jar = "dbfs:/FileStore/lib.jar"
+		}`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition cannot have "+
+		"libraries element, use library blocks instead")
+}
+
+func TestResourceClusterPolicyPlan_LibrariesOnlyInDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		definition = "{\"libraries\":{\"type\":\"fixed\",\"value\":[{\"pypi\":{\"package\":\"requests\"}}]}}"`,
+		Plan: true,
+	}.ExpectError(t, "Invalid config supplied. [definition] definition cannot have "+
+		"libraries element, use library blocks instead")
+}
+
+func TestResourceClusterPolicyReadPlan_LibrariesRoundTrip(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Dummy",
+					Definition: `{"libraries": {"type": "fixed", "value": [` +
+						`{"pypi": {"package": "requests"}}]}, ` +
+						`"spark_conf.foo": {"type": "fixed", "value": "bar"}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	diff, err := ResourceClusterPolicy().Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":       "Dummy",
+			"definition": `{"spark_conf.foo": {"type": "fixed", "value": "bar"}}`,
+			"library": []interface{}{
+				map[string]interface{}{
+					"pypi": []interface{}{
+						map[string]interface{}{
+							"package": "requests",
+						},
+					},
+				},
+			},
+		}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || len(diff.Attributes) == 0, diff)
+}
+
+func TestResourceClusterPolicyDiff_ReformattedDefinition(t *testing.T) {
+	// definition is serialized compactly, when libraries are extracted during read
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"name":       "Dummy",
+			"policy_id":  "abc",
+			"definition": `{"autotermination_minutes":{"type":"fixed","value":20},"spark_version":{"type":"fixed","value":"7.3.x-scala2.12"}}`,
+		},
+	}
+	diff, err := ResourceClusterPolicy().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "Dummy",
+			"definition": `{
+				"spark_version": {"type": "fixed", "value": "7.3.x-scala2.12"},
+				"autotermination_minutes": {"type": "fixed", "value": 20}
+			}`,
+		}), nil)
+	assert.NoError(t, err)
+	assert.True(t, diff == nil || diff.Attributes["definition"] == nil, diff)
+}
+
+func TestSuppressEquivalentPolicyDefinition(t *testing.T) {
+	assert.True(t, suppressEquivalentPolicyDefinition("definition",
+		`{"a":{"type":"fixed","value":1}}`, `{ "a": { "value": 1, "type": "fixed" } }`, nil))
+	assert.False(t, suppressEquivalentPolicyDefinition("definition",
+		`{"a":{"type":"fixed","value":1}}`, `{"a":{"type":"fixed","value":2}}`, nil))
+	assert.False(t, suppressEquivalentPolicyDefinition("definition", `{}`, `not json`, nil))
+}
@@ -81,6 +81,139 @@ func TestResourceClusterCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceClusterCreate_DockerBasicAuthFromSecrets(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/secrets/list?scope=registry",
+				Response: secretKeys{
+					Secrets: []struct {
+						Key string `json:"key"`
+					}{
+						{Key: "username"},
+						{Key: "password"},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             100,
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					DockerImage: &DockerImage{
+						URL: "acme.azurecr.io/sample:latest",
+						BasicAuth: &DockerBasicAuth{
+							Username: "{{secrets/registry/username}}",
+							Password: "{{secrets/registry/password}}",
+						},
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateRunning,
+					DockerImage: &DockerImage{
+						URL: "acme.azurecr.io/sample:latest",
+						BasicAuth: &DockerBasicAuth{
+							Username: "{{secrets/registry/username}}",
+							Password: "{{secrets/registry/password}}",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		autotermination_minutes = 15
+		cluster_name = "Shared Autoscaling"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 100
+		docker_image {
+			url = "acme.azurecr.io/sample:latest"
+			basic_auth {
+				username = "{{secrets/registry/username}}"
+				password = "{{secrets/registry/password}}"
+			}
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "{{secrets/registry/password}}", d.Get("docker_image.0.basic_auth.0.password"))
+	password, err := common.SchemaPath(clusterSchema, "docker_image", "basic_auth", "password")
+	require.NoError(t, err, err)
+	assert.True(t, password.Sensitive)
+}
+
+func TestResourceClusterCreate_DockerBasicAuthMissingSecret(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/secrets/list?scope=registry",
+				Response: secretKeys{
+					Secrets: []struct {
+						Key string `json:"key"`
+					}{
+						{Key: "username"},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 100
+		docker_image {
+			url = "acme.azurecr.io/sample:latest"
+			basic_auth {
+				username = "{{secrets/registry/username}}"
+				password = "{{secrets/registry/password}}"
+			}
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "docker_image.basic_auth.password refers to a missing secret password in registry scope")
+}
+
 func TestResourceClusterCreatePinned(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -455,6 +588,103 @@ func TestResourceClusterRead(t *testing.T) {
 	}
 }
 
+func TestResourceClusterRead_IgnoresPlatformSparkEnvVars_ReportsOtherDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateTerminated,
+					SparkEnvVars: map[string]string{
+						"FOO":            "bar",
+						"PYSPARK_PYTHON": "/databricks/python3/bin/python3",
+						"ADDED_OUTSIDE":  "true",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		State: map[string]interface{}{
+			"spark_env_vars": map[string]interface{}{
+				"FOO": "bar",
+			},
+		},
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"FOO":           "bar",
+		"ADDED_OUTSIDE": "true",
+	}, d.Get("spark_env_vars"))
+}
+
+func TestResourceClusterRead_Import_SkipsPlatformSparkEnvVars(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateTerminated,
+					SparkEnvVars: map[string]string{
+						"FOO":            "bar",
+						"PYSPARK_PYTHON": "/databricks/python3/bin/python3",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		ID:       "abc",
+		New:      true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{"FOO": "bar"}, d.Get("spark_env_vars"))
+}
+
 func TestResourceClusterRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -40,6 +41,14 @@ func (a InstancePoolsAPI) Read(instancePoolID string) (ip InstancePool, err erro
 	return
 }
 
+// Get retrieves the information for a instance pool along with its current usage stats
+func (a InstancePoolsAPI) Get(instancePoolID string) (ip InstancePoolAndStats, err error) {
+	err = a.client.Get(a.context, "/instance-pools/get", map[string]string{
+		"instance_pool_id": instancePoolID,
+	}, &ip)
+	return
+}
+
 // List retrieves the list of existing instance pools
 func (a InstancePoolsAPI) List() (ipl InstancePoolList, err error) {
 	err = a.client.Get(a.context, "/instance-pools/list", nil, &ipl)
@@ -89,7 +98,7 @@ func ResourceInstancePool() *schema.Resource {
 		}
 		return s
 	})
-	return common.Resource{
+	r := common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ip InstancePool
@@ -122,4 +131,23 @@ func ResourceInstancePool() *schema.Resource {
 			return NewInstancePoolsAPI(ctx, c).Delete(d.Id())
 		},
 	}.ToResource()
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		return validateInstancePoolCapacity(d)
+	}
+	return r
+}
+
+// validateInstancePoolCapacity fails the plan, if the pool could never keep all the idle
+// instances. Pools without max_capacity have no upper bound.
+func validateInstancePoolCapacity(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("max_capacity") || !d.NewValueKnown("min_idle_instances") {
+		return nil
+	}
+	maxCapacity := d.Get("max_capacity").(int)
+	minIdleInstances := d.Get("min_idle_instances").(int)
+	if maxCapacity > 0 && maxCapacity < minIdleInstances {
+		return fmt.Errorf("max_capacity (%d) must be greater than or equal to min_idle_instances (%d)",
+			maxCapacity, minIdleInstances)
+	}
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccInstancePools(t *testing.T) {
@@ -115,6 +116,67 @@ func TestResourceInstancePoolCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceInstancePoolCreate_MaxCapacityBelowMinIdle(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		min_idle_instances = 10
+		max_capacity = 5
+		`,
+		Create: true,
+	}.ExpectError(t, "max_capacity (5) must be greater than or equal to min_idle_instances (10)")
+}
+
+func TestResourceInstancePoolCreate_NoMaxCapacity(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					MinIdleInstances:                   10,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedSparkVersions:             []string{"7.3.x-scala2.12"},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					MinIdleInstances:                   10,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedSparkVersions:             []string{"7.3.x-scala2.12"},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		min_idle_instances = 10
+		preloaded_spark_versions = ["7.3.x-scala2.12"]
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, []interface{}{"7.3.x-scala2.12"}, d.Get("preloaded_spark_versions"))
+}
+
 func TestResourceInstancePoolCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -149,6 +149,8 @@ var jobSchema = common.StructToSchema(JobSettings{},
 			"Run Now in the Jobs UI or sending an API request to runNow."
 		s["max_concurrent_runs"].Description = "An optional maximum allowed number of " +
 			"concurrent runs of the job."
+		s["run_job_task"].Description = "An optional task, that triggers run of " +
+			"another job with the given job_id."
 		s["url"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Computed: true,
@@ -156,6 +158,18 @@ var jobSchema = common.StructToSchema(JobSettings{},
 		return s
 	})
 
+// validateRunJobTask checks, that job triggered by run_job_task exists
+func validateRunJobTask(jobsAPI JobsAPI, js JobSettings) error {
+	if js.RunJobTask == nil {
+		return nil
+	}
+	_, err := jobsAPI.Read(fmt.Sprintf("%d", js.RunJobTask.JobID))
+	if e, ok := err.(common.APIError); ok && e.IsMissing() {
+		return fmt.Errorf("run_job_task refers to missing job %d", js.RunJobTask.JobID)
+	}
+	return err
+}
+
 // ResourceJob ...
 func ResourceJob() *schema.Resource {
 	return common.Resource{
@@ -172,7 +186,11 @@ func ResourceJob() *schema.Resource {
 					return err
 				}
 			}
-			job, err := NewJobsAPI(ctx, c).Create(js)
+			jobsAPI := NewJobsAPI(ctx, c)
+			if err = validateRunJobTask(jobsAPI, js); err != nil {
+				return err
+			}
+			job, err := jobsAPI.Create(js)
 			if err != nil {
 				return err
 			}
@@ -199,7 +217,11 @@ func ResourceJob() *schema.Resource {
 					return err
 				}
 			}
-			return NewJobsAPI(ctx, c).Update(d.Id(), js)
+			jobsAPI := NewJobsAPI(ctx, c)
+			if err = validateRunJobTask(jobsAPI, js); err != nil {
+				return err
+			}
+			return jobsAPI.Update(d.Id(), js)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewJobsAPI(ctx, c).Delete(d.Id())
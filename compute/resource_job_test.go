@@ -91,6 +91,72 @@ func TestResourceJobCreate(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreate_NotebookTaskWithSchedule(t *testing.T) {
+	settings := JobSettings{
+		Name:              "Nightly",
+		ExistingClusterID: "abc",
+		NotebookTask: &NotebookTask{
+			NotebookPath: "/Shared/nightly",
+			BaseParameters: map[string]string{
+				"env": "prod",
+			},
+		},
+		Libraries: []Library{
+			{
+				Whl: "dbfs:/FileStore/nightly.whl",
+			},
+		},
+		MaxRetries: 2,
+		Schedule: &CronSchedule{
+			QuartzCronExpression: "0 0 2 * * ?",
+			TimezoneID:           "Europe/Amsterdam",
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/jobs/create",
+				ExpectedRequest: settings,
+				Response: Job{
+					JobID: 790,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=790",
+				Response: Job{
+					JobID:    790,
+					Settings: &settings,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `name = "Nightly"
+		existing_cluster_id = "abc"
+		max_retries = 2
+
+		notebook_task {
+			notebook_path = "/Shared/nightly"
+			base_parameters = {
+				env = "prod"
+			}
+		}
+		library {
+			whl = "dbfs:/FileStore/nightly.whl"
+		}
+		schedule {
+			quartz_cron_expression = "0 0 2 * * ?"
+			timezone_id = "Europe/Amsterdam"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "790", d.Id())
+	assert.Equal(t, "0 0 2 * * ?", d.Get("schedule.0.quartz_cron_expression"))
+	assert.Equal(t, "/Shared/nightly", d.Get("notebook_task.0.notebook_path"))
+}
+
 func TestResourceJobCreateSingleNode(t *testing.T) {
 	cluster := Cluster{
 		NumWorkers: 0, SparkVersion: "7.3.x-scala2.12", NodeTypeID: "Standard_DS3_v2",
@@ -233,6 +299,205 @@ func TestResourceJobCreateNWorkers(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreateWithQueue(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					Name:              "Queued",
+					MaxConcurrentRuns: 1,
+					Queue: &JobQueue{
+						Enabled: true,
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Name:              "Queued",
+						MaxConcurrentRuns: 1,
+						Queue: &JobQueue{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		name = "Queued"
+
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+
+		queue {
+			enabled = true
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, true, d.Get("queue.0.enabled"))
+}
+
+func TestResourceJobCreateWithNotificationSettings(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					Name:              "Quiet",
+					MaxConcurrentRuns: 1,
+					NotificationSettings: &JobNotificationSettings{
+						NoAlertForSkippedRuns: true,
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Name:              "Quiet",
+						MaxConcurrentRuns: 1,
+						NotificationSettings: &JobNotificationSettings{
+							NoAlertForSkippedRuns: true,
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		name = "Quiet"
+
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+
+		notification_settings {
+			no_alert_for_skipped_runs = true
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, true, d.Get("notification_settings.0.no_alert_for_skipped_runs"))
+	assert.Equal(t, false, d.Get("notification_settings.0.no_alert_for_canceled_runs"))
+}
+
+func TestResourceJobCreateWithRunJobTask(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=123",
+				Response: Job{
+					JobID: 123,
+					Settings: &JobSettings{
+						Name: "Downstream",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					RunJobTask: &RunJobTask{
+						JobID: 123,
+					},
+					Name: "Trigger",
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						RunJobTask: &RunJobTask{
+							JobID: 123,
+						},
+						Name: "Trigger",
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		name = "Trigger"
+
+		run_job_task {
+			job_id = 123
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, 123, d.Get("run_job_task.0.job_id"))
+}
+
+func TestResourceJobCreateWithRunJobTask_MissingJob(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=123",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Job 123 does not exist.",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		name = "Trigger"
+
+		run_job_task {
+			job_id = 123
+		}`,
+	}.ExpectError(t, "run_job_task refers to missing job 123")
+}
+
 func TestResourceJobCreateSingleNode_Fail(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Create:   true,
@@ -52,6 +52,9 @@ func TestImportingMounts(t *testing.T) {
 						{
 							ClusterName: "terraform-mount-shard-s3-access",
 							ClusterID:   "mount",
+							AwsAttributes: &compute.AwsAttributes{
+								InstanceProfileArn: "arn:aws:iam::12345:instance-profile/shard-s3-access",
+							},
 						},
 					},
 				},
@@ -96,7 +99,7 @@ func TestImportingMounts(t *testing.T) {
 				Resource:     "/api/1.2/commands/status?clusterId=mount&commandId=run&contextId=context",
 				Response: compute.Command{
 					Status: "Finished",
-					Results: &compute.CommandResults{
+					Results: &common.CommandResults{
 						ResultType: "text",
 						Data: `{"foo": "s3a://foo", "bar": "abfss://bar@baz.com/thing", "third": "adls://foo.bar.com/path"}
 					and some chatty messages`,
@@ -106,16 +106,10 @@ func (ic *importContext) cacheGroups() error {
 // }
 
 func (ic *importContext) findUserByName(name string) (u identity.ScimUser, err error) {
-	a := identity.NewUsersAPI(ic.Context, ic.Client)
-	users, err := a.Filter(fmt.Sprintf("userName eq '%s'", name))
-	if err != nil {
-		return
-	}
-	if len(users) == 0 {
+	u, found, err := identity.NewUsersAPI(ic.Context, ic.Client).ReadByUserName(name)
+	if err == nil && !found {
 		err = fmt.Errorf("User %s not found", name)
-		return
 	}
-	u = users[0]
 	return
 	// if err := ic.cacheUsers(); err != nil {
 	// 	return
@@ -171,8 +165,8 @@ func (ic *importContext) refreshMounts() error {
 		}
 		for _, instanceProfile := range profiles {
 			log.Printf("[INFO] Refreshing mounts accessible by %s", instanceProfile.InstanceProfileArn)
-			profileCluster, err := storage.GetOrCreateMountingClusterWithInstanceProfile(
-				clustersAPI, instanceProfile.InstanceProfileArn)
+			profileCluster, err := storage.GetOrCreateTaggedMountingCluster(
+				ic.Context, ic.Client, instanceProfile.InstanceProfileArn)
 			if err != nil {
 				return err
 			}
@@ -236,11 +230,12 @@ println(mapper.writeValueAsString(readableMounts))`
 func (ic *importContext) getMountsThroughCluster(
 	commandAPI common.CommandExecutor, clusterID string) (mm map[string]string, err error) {
 	// Scala has actually working timeout handling, compared to Python
-	j, err := commandAPI.Execute(clusterID, "scala", getReadableMountsCommand)
-	if err != nil {
+	result := commandAPI.Execute(clusterID, "scala", getReadableMountsCommand)
+	if result.Failed() {
+		err = result.Err()
 		return
 	}
-	lines := strings.Split(j, "\n")
+	lines := strings.Split(result.Text(), "\n")
 	err = json.Unmarshal([]byte(lines[0]), &mm)
 	return
 }
@@ -28,6 +28,14 @@ func DataSourceCurrentUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"is_admin": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_service_principal": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			usersAPI := NewUsersAPI(ctx, m)
@@ -35,9 +43,16 @@ func DataSourceCurrentUser() *schema.Resource {
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			d.Set("user_name", me.UserName)
-			d.Set("home", fmt.Sprintf("/Users/%s", me.UserName))
-			splits := strings.Split(me.UserName, "@")
+			userName := me.UserName
+			if userName == "" {
+				// service principals are identified by application id
+				userName = me.ApplicationID
+			}
+			d.Set("user_name", userName)
+			d.Set("home", fmt.Sprintf("/Users/%s", userName))
+			d.Set("is_admin", me.isAdmin())
+			d.Set("is_service_principal", me.ApplicationID != "")
+			splits := strings.Split(userName, "@")
 			norm := nonAlphanumeric.ReplaceAllLiteralString(splits[0], "_")
 			norm = strings.ToLower(norm)
 			d.Set("alphanumeric", norm)
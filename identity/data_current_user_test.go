@@ -30,4 +30,59 @@ func TestDataSourceCurrentUser(t *testing.T) {
 	assert.Equal(t, d.Get("user_name"), "mr.test@example.com")
 	assert.Equal(t, d.Get("home"), "/Users/mr.test@example.com")
 	assert.Equal(t, d.Get("alphanumeric"), "mr_test")
+	assert.Equal(t, false, d.Get("is_admin"))
+	assert.Equal(t, false, d.Get("is_service_principal"))
+}
+
+func TestDataSourceCurrentUser_Admin(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: ScimUser{
+					ID:       "123",
+					UserName: "mr.test@example.com",
+					Groups: []GroupsListItem{
+						{
+							Display: "admins",
+							Value:   "456",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCurrentUser(),
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, true, d.Get("is_admin"))
+}
+
+func TestDataSourceCurrentUser_ServicePrincipal(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: ScimUser{
+					ID:            "123",
+					ApplicationID: "00000000-aaaa-bbbb-cccc-000000000000",
+					DisplayName:   "Automation",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCurrentUser(),
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, "00000000-aaaa-bbbb-cccc-000000000000", d.Get("user_name"))
+	assert.Equal(t, "/Users/00000000-aaaa-bbbb-cccc-000000000000", d.Get("home"))
+	assert.Equal(t, "00000000_aaaa_bbbb_cccc_000000000000", d.Get("alphanumeric"))
+	assert.Equal(t, true, d.Get("is_service_principal"))
 }
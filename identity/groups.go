@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -49,13 +50,49 @@ func (a GroupsAPI) Create(groupName string, members []string, roles []string, en
 	for _, entitlement := range entitlements {
 		scimGroupRequest.Entitlements = append(scimGroupRequest.Entitlements, ValueListItem{Value: entitlement})
 	}
-	err = a.client.Scim(a.context, http.MethodPost, "/preview/scim/v2/Groups", scimGroupRequest, &group)
+	err = a.client.Scim(a.context, http.MethodPost, "/Groups", scimGroupRequest, &group)
 	return
 }
 
+// CreateOrAdopt creates a scim group or returns the existing group with the same
+// display name, e.g. provisioned by identity provider, telling that it was adopted
+func (a GroupsAPI) CreateOrAdopt(groupName string, members []string, roles []string,
+	entitlements []string) (group ScimGroup, adopted bool, err error) {
+	group, err = a.Create(groupName, members, roles, entitlements)
+	if e, ok := err.(common.APIError); !ok || !e.IsConflict() {
+		return
+	}
+	log.Printf("[INFO] Group %s already exists: %s", groupName, err)
+	group, err = a.ReadByDisplayName(groupName)
+	return group, err == nil, err
+}
+
+// ReadByDisplayName returns the group with the given display name. Server-side filter
+// is preferred, but if SCIM endpoint rejects it, all groups are listed page by page
+func (a GroupsAPI) ReadByDisplayName(displayName string) (group ScimGroup, err error) {
+	groupList, err := a.Filter(scimEqFilter("displayName", displayName))
+	if e, ok := err.(common.APIError); ok && e.StatusCode == http.StatusBadRequest {
+		log.Printf("[INFO] Cannot filter groups, listing all of them: %s", err)
+		groupList.Resources, err = a.List(0)
+		for _, g := range groupList.Resources {
+			if g.DisplayName == displayName {
+				return g, nil
+			}
+		}
+		groupList.Resources = nil
+	}
+	if err != nil {
+		return
+	}
+	if len(groupList.Resources) == 0 {
+		return group, fmt.Errorf("Cannot find group %s", displayName)
+	}
+	return groupList.Resources[0], nil
+}
+
 // Read reads and returns a Group object via SCIM api
 func (a GroupsAPI) Read(groupID string) (group ScimGroup, err error) {
-	err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID), nil, &group)
+	err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf("/Groups/%v", groupID), nil, &group)
 	if err != nil {
 		return
 	}
@@ -69,18 +106,50 @@ func (a GroupsAPI) Filter(filter string) (GroupList, error) {
 	if filter != "" {
 		req["filter"] = filter
 	}
-	err := a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Groups", req, &groups)
+	err := a.client.Scim(a.context, http.MethodGet, "/Groups", req, &groups)
 	return groups, err
 }
 
+// defaultGroupsPageSize is the number of groups fetched within a single list request
+const defaultGroupsPageSize = 100
+
+// scimPageRequest selects a page of SCIM resources, where the first one has index of 1
+type scimPageRequest struct {
+	StartIndex int `url:"startIndex"`
+	Count      int `url:"count"`
+}
+
+// List returns all groups of the workspace, following SCIM pagination until totalResults
+// are fetched. Non-positive page size means the default one.
+func (a GroupsAPI) List(pageSize int) (groups []ScimGroup, err error) {
+	if pageSize <= 0 {
+		pageSize = defaultGroupsPageSize
+	}
+	for startIndex := 1; ; {
+		var page GroupList
+		err = a.client.Scim(a.context, http.MethodGet, "/Groups", scimPageRequest{
+			StartIndex: startIndex,
+			Count:      pageSize,
+		}, &page)
+		if err != nil {
+			return
+		}
+		groups = append(groups, page.Resources...)
+		if len(page.Resources) == 0 || len(groups) >= int(page.TotalResults) {
+			return
+		}
+		startIndex += len(page.Resources)
+	}
+}
+
 // PatchR ...
 func (a GroupsAPI) PatchR(groupID string, r patchRequest) error {
-	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID), r, nil)
+	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/Groups/%v", groupID), r, nil)
 }
 
 // Patch applys a patch request for a group given a path attribute
 func (a GroupsAPI) Patch(groupID string, addList []string, removeList []string, path GroupPathType) error {
-	groupPath := fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID)
+	groupPath := fmt.Sprintf("/Groups/%v", groupID)
 
 	var addOperations GroupPatchOperations
 	var removeOperations GroupPatchOperations
@@ -107,7 +176,7 @@ func (a GroupsAPI) Patch(groupID string, addList []string, removeList []string,
 	}
 
 	for _, removeItem := range removeList {
-		path := fmt.Sprintf("%s[value eq \"%s\"]", string(path), removeItem)
+		path := scimValueFilter(string(path), removeItem)
 		removeOperations = GroupPatchOperations{
 			Op:   "remove",
 			Path: GroupPathType(path),
@@ -120,6 +189,6 @@ func (a GroupsAPI) Patch(groupID string, addList []string, removeList []string,
 // Delete deletes a group given a group id
 func (a GroupsAPI) Delete(groupID string) error {
 	return a.client.Scim(a.context, http.MethodDelete,
-		fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID),
+		fmt.Sprintf("/Groups/%v", groupID),
 		nil, nil)
 }
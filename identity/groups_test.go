@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
@@ -68,3 +69,102 @@ func TestAccFilterGroup(t *testing.T) {
 	assert.NotNil(t, groupList)
 	assert.Len(t, groupList.Resources, 1)
 }
+
+func TestGroupsAPIRead_RetriesServerErrors(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+			Status:   503,
+			Response: common.APIErrorBody{
+				ErrorCode: "TEMPORARILY_UNAVAILABLE",
+				Message:   "Please try again later",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+			Response: ScimGroup{
+				ID:          "abc",
+				DisplayName: "Data Scientists",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+	client.RetryDelay = 10 * time.Millisecond
+	err = client.Configure()
+	require.NoError(t, err)
+
+	group, err := NewGroupsAPI(context.Background(), client).Read("abc")
+	require.NoError(t, err)
+	assert.Equal(t, "Data Scientists", group.DisplayName)
+}
+
+func TestGroupsAPIList_Pages(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?count=2&startIndex=1",
+			Response: GroupList{
+				TotalResults: 3,
+				StartIndex:   1,
+				ItemsPerPage: 2,
+				Resources: []ScimGroup{
+					{ID: "a", DisplayName: "admins"},
+					{ID: "b", DisplayName: "users"},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?count=2&startIndex=3",
+			Response: GroupList{
+				TotalResults: 3,
+				StartIndex:   3,
+				ItemsPerPage: 1,
+				Resources: []ScimGroup{
+					{ID: "c", DisplayName: "Data Scientists"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	groups, err := NewGroupsAPI(context.Background(), client).List(2)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "c", groups[2].ID)
+}
+
+func TestGroupsAPIReadByDisplayName_FallbackToList(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%27Data%20Scientists%27",
+			Status:   400,
+			Response: common.APIErrorBody{
+				ScimDetail: "Unsupported filter",
+				ScimStatus: "400",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?count=100&startIndex=1",
+			Response: GroupList{
+				TotalResults: 2,
+				Resources: []ScimGroup{
+					{ID: "a", DisplayName: "admins"},
+					{ID: "c", DisplayName: "Data Scientists"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	group, err := NewGroupsAPI(context.Background(), client).ReadByDisplayName("Data Scientists")
+	require.NoError(t, err)
+	assert.Equal(t, "c", group.ID)
+}
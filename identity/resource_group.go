@@ -3,10 +3,12 @@ package identity
 import (
 	"context"
 	"log"
+	"sort"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceGroup manages user groups
@@ -24,13 +26,21 @@ func ResourceGroup() *schema.Resource {
 		if err = d.Set("display_name", group.DisplayName); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = d.Set("allow_cluster_create", isGroupClusterCreateEntitled(&group)); err != nil {
+		if err = readGroupEntitlements(d, group); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = d.Set("allow_sql_analytics_access", isGroupSQLAnalyticsAccessEntitled(&group)); err != nil {
+		members := []string{}
+		for _, member := range group.Members {
+			members = append(members, member.Value)
+		}
+		if err = readGroupSet(d, "members", members, d.Get("external_members").(bool)); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = d.Set("allow_instance_pool_create", isGroupInstancePoolCreateEntitled(&group)); err != nil {
+		roles := []string{}
+		for _, role := range group.Roles {
+			roles = append(roles, role.Value)
+		}
+		if err = readGroupSet(d, "roles", roles, false); err != nil {
 			return diag.FromErr(err)
 		}
 		return nil
@@ -53,11 +63,34 @@ func ResourceGroup() *schema.Resource {
 			if allowInstancePoolCreate {
 				entitlementsList = append(entitlementsList, string(AllowInstancePoolCreateEntitlement))
 			}
-			group, err := NewGroupsAPI(ctx, m).Create(groupName, nil, nil, entitlementsList)
+			entitlementsList = append(entitlementsList, groupMembersList(d.Get("entitlements"))...)
+			// initial members and roles are sent within the same create request
+			members := groupMembersList(d.Get("members"))
+			roles := groupMembersList(d.Get("roles"))
+			groupsAPI := NewGroupsAPI(ctx, m)
+			if !d.Get("force").(bool) {
+				group, err := groupsAPI.Create(groupName, members, roles, entitlementsList)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				d.SetId(group.ID)
+				return readContext(ctx, d, m)
+			}
+			group, adopted, err := groupsAPI.CreateOrAdopt(groupName, members, roles, entitlementsList)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 			d.SetId(group.ID)
+			if err = d.Set("adopted", adopted); err != nil {
+				return diag.FromErr(err)
+			}
+			if adopted {
+				log.Printf("[INFO] Adopting existing group %s with id %s", groupName, group.ID)
+				if err = convergeAdoptedGroup(groupsAPI, group, members, roles, entitlementsList,
+					adoptedGroupEntitlements(d, group)); err != nil {
+					return diag.FromErr(err)
+				}
+			}
 			return readContext(ctx, d, m)
 		},
 		UpdateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -86,13 +119,13 @@ func ResourceGroup() *schema.Resource {
 			}
 			// If allow_instance_pool_create has changed
 			if d.HasChange("allow_instance_pool_create") {
-				allowClusterCreate := d.Get("allow_instance_pool_create").(bool)
+				allowInstancePoolCreate := d.Get("allow_instance_pool_create").(bool)
 				// Changed to true
-				if allowClusterCreate {
-					entitlementsAddList = append(entitlementsAddList, string(AllowClusterCreateEntitlement))
+				if allowInstancePoolCreate {
+					entitlementsAddList = append(entitlementsAddList, string(AllowInstancePoolCreateEntitlement))
 				}
 				// Changed to false
-				entitlementsRemoveList = append(entitlementsRemoveList, string(AllowClusterCreateEntitlement))
+				entitlementsRemoveList = append(entitlementsRemoveList, string(AllowInstancePoolCreateEntitlement))
 			}
 			// TODO: not currently possible to update group display name
 			if entitlementsAddList != nil || entitlementsRemoveList != nil {
@@ -102,10 +135,43 @@ func ResourceGroup() *schema.Resource {
 					return diag.FromErr(err)
 				}
 			}
+			if d.HasChange("entitlements") {
+				o, n := d.GetChange("entitlements")
+				entitlementsAddList := groupMembersList(n.(*schema.Set).Difference(o.(*schema.Set)))
+				entitlementsRemoveList := groupMembersList(o.(*schema.Set).Difference(n.(*schema.Set)))
+				if entitlementsAddList != nil || entitlementsRemoveList != nil {
+					if err := NewGroupsAPI(ctx, m).Patch(d.Id(), entitlementsAddList,
+						entitlementsRemoveList, GroupEntitlementsPath); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+			if d.HasChange("members") {
+				o, n := d.GetChange("members")
+				if err := updateGroupMembers(NewGroupsAPI(ctx, m), d,
+					o.(*schema.Set), n.(*schema.Set)); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			if d.HasChange("roles") {
+				o, n := d.GetChange("roles")
+				rolesAddList := groupMembersList(n.(*schema.Set).Difference(o.(*schema.Set)))
+				rolesRemoveList := groupMembersList(o.(*schema.Set).Difference(n.(*schema.Set)))
+				if rolesAddList != nil || rolesRemoveList != nil {
+					if err := NewGroupsAPI(ctx, m).Patch(d.Id(),
+						rolesAddList, rolesRemoveList, GroupRolesPath); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
 			return nil
 		},
 		ReadContext: readContext,
 		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if d.Get("adopted").(bool) && !d.Get("force_delete").(bool) {
+				log.Printf("[INFO] Not deleting group %s, as it existed before Terraform adopted it", d.Id())
+				return nil
+			}
 			if err := NewGroupsAPI(ctx, m).Delete(d.Id()); err != nil {
 				return diag.FromErr(err)
 			}
@@ -121,44 +187,241 @@ func ResourceGroup() *schema.Resource {
 				Required: true,
 			},
 			"allow_cluster_create": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"entitlements"},
+			},
+			"allow_sql_analytics_access": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"entitlements"},
+			},
+			"allow_instance_pool_create": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"entitlements"},
+			},
+			"entitlements": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(AllowClusterCreateEntitlement),
+						string(AllowInstancePoolCreateEntitlement),
+						string(AllowSQLAnalyticsAccessEntitlement),
+						string(DatabricksSQLAccessEntitlement),
+					}, false),
+				},
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"external_members": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			"allow_sql_analytics_access": {
+			"roles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			"allow_instance_pool_create": {
+			"force_delete": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"adopted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func isGroupClusterCreateEntitled(group *ScimGroup) bool {
+// managedGroupEntitlements are entitlements, that have flags in group resource
+var managedGroupEntitlements = []Entitlement{
+	AllowClusterCreateEntitlement,
+	AllowSQLAnalyticsAccessEntitlement,
+	AllowInstancePoolCreateEntitlement,
+}
+
+// readGroupEntitlements sets the entitlements set, if it's managed inline, or allow_* flags
+// otherwise, so that the same entitlement is never reported by both of them
+func readGroupEntitlements(d *schema.ResourceData, group ScimGroup) error {
+	entitlements := []string{}
 	for _, entitlement := range group.Entitlements {
-		if entitlement.Value == AllowClusterCreateEntitlement {
-			return true
-		}
+		entitlements = append(entitlements, string(entitlement.Value))
+	}
+	if d.Get("entitlements").(*schema.Set).Len() > 0 {
+		return readGroupSet(d, "entitlements", entitlements, false)
+	}
+	if err := d.Set("allow_cluster_create", group.HasEntitlement(AllowClusterCreateEntitlement)); err != nil {
+		return err
 	}
-	return false
+	if err := d.Set("allow_sql_analytics_access", group.HasEntitlement(AllowSQLAnalyticsAccessEntitlement)); err != nil {
+		return err
+	}
+	return d.Set("allow_instance_pool_create", group.HasEntitlement(AllowInstancePoolCreateEntitlement))
 }
 
-func isGroupSQLAnalyticsAccessEntitled(group *ScimGroup) bool {
+// adoptedGroupEntitlements returns entitlements, that are converged on the adopted group:
+// all of its entitlements with the inline set, or the ones with allow_* flags otherwise
+func adoptedGroupEntitlements(d *schema.ResourceData, group ScimGroup) []Entitlement {
+	if d.Get("entitlements").(*schema.Set).Len() == 0 {
+		return managedGroupEntitlements
+	}
+	managed := []Entitlement{}
 	for _, entitlement := range group.Entitlements {
-		if entitlement.Value == AllowSQLAnalyticsAccessEntitlement {
-			return true
+		managed = append(managed, entitlement.Value)
+	}
+	for _, entitlement := range groupMembersList(d.Get("entitlements")) {
+		managed = append(managed, Entitlement(entitlement))
+	}
+	return managed
+}
+
+// convergeAdoptedGroup adds configured members, roles and entitlements to the existing group
+// and removes managed entitlements, that are not configured. Other members and roles are kept.
+func convergeAdoptedGroup(groupsAPI GroupsAPI, group ScimGroup, members, roles, entitlements []string,
+	managed []Entitlement) error {
+	var membersAddList []string
+	for _, member := range members {
+		if !group.HasMember(member) {
+			membersAddList = append(membersAddList, member)
+		}
+	}
+	if membersAddList != nil {
+		if err := groupsAPI.Patch(group.ID, membersAddList, nil, GroupMembersPath); err != nil {
+			return err
+		}
+	}
+	var rolesAddList []string
+	for _, role := range roles {
+		if !group.HasRole(role) {
+			rolesAddList = append(rolesAddList, role)
+		}
+	}
+	if rolesAddList != nil {
+		if err := groupsAPI.Patch(group.ID, rolesAddList, nil, GroupRolesPath); err != nil {
+			return err
+		}
+	}
+	desired := map[string]bool{}
+	for _, entitlement := range entitlements {
+		desired[entitlement] = true
+	}
+	var entitlementsAddList, entitlementsRemoveList []string
+	seen := map[Entitlement]bool{}
+	for _, entitlement := range managed {
+		if seen[entitlement] {
+			continue
+		}
+		seen[entitlement] = true
+		has := group.HasEntitlement(entitlement)
+		if desired[string(entitlement)] && !has {
+			entitlementsAddList = append(entitlementsAddList, string(entitlement))
 		}
+		if !desired[string(entitlement)] && has {
+			entitlementsRemoveList = append(entitlementsRemoveList, string(entitlement))
+		}
+	}
+	if entitlementsAddList == nil && entitlementsRemoveList == nil {
+		return nil
 	}
-	return false
+	return groupsAPI.Patch(group.ID, entitlementsAddList, entitlementsRemoveList, GroupEntitlementsPath)
 }
 
-func isGroupInstancePoolCreateEntitled(group *ScimGroup) bool {
-	for _, entitlement := range group.Entitlements {
-		if entitlement.Value == AllowClusterCreateEntitlement {
-			return true
+// readGroupSet sets members or roles of the group, if they are managed inline. Groups
+// without them in state are left alone, as databricks_group_member and databricks_group_role
+// resources might manage them instead. With keepExternal, only the values from state are
+// checked, so that members added outside of Terraform, e.g. by SCIM sync of the identity
+// provider, are neither reported as drift nor removed.
+func readGroupSet(d *schema.ResourceData, key string, actual []string, keepExternal bool) error {
+	current := d.Get(key).(*schema.Set)
+	if current.Len() == 0 {
+		return nil
+	}
+	values := []interface{}{}
+	for _, value := range actual {
+		if keepExternal && !current.Contains(value) {
+			continue
+		}
+		values = append(values, value)
+	}
+	return d.Set(key, values)
+}
+
+// updateGroupMembers applies membership changes with a single patch request. If some of the
+// removed members are already gone, changes are re-applied one by one, tolerating missing
+// members. On any other error, members are re-read, so that state reflects remaining ones.
+func updateGroupMembers(groupsAPI GroupsAPI, d *schema.ResourceData, oldMembers, newMembers *schema.Set) error {
+	membersAddList := groupMembersList(newMembers.Difference(oldMembers))
+	membersRemoveList := groupMembersList(oldMembers.Difference(newMembers))
+	if membersAddList == nil && membersRemoveList == nil {
+		return nil
+	}
+	err := groupsAPI.Patch(d.Id(), membersAddList, membersRemoveList, GroupMembersPath)
+	if e, ok := err.(common.APIError); ok && e.IsMissing() && membersRemoveList != nil {
+		log.Printf("[WARN] Some of members are already removed from group %s: %s", d.Id(), e)
+		err = patchGroupMembersOneByOne(groupsAPI, d.Id(), membersAddList, membersRemoveList)
+	}
+	if err == nil {
+		return nil
+	}
+	group, readErr := groupsAPI.Read(d.Id())
+	if readErr != nil {
+		log.Printf("[WARN] Cannot re-read members of group %s: %s", d.Id(), readErr)
+		return err
+	}
+	remaining := []interface{}{}
+	for _, member := range oldMembers.Union(newMembers).List() {
+		if group.HasMember(member.(string)) {
+			remaining = append(remaining, member)
+		}
+	}
+	if setErr := d.Set("members", remaining); setErr != nil {
+		return setErr
+	}
+	return err
+}
+
+func patchGroupMembersOneByOne(groupsAPI GroupsAPI, groupID string, membersAddList, membersRemoveList []string) error {
+	if membersAddList != nil {
+		if err := groupsAPI.Patch(groupID, membersAddList, nil, GroupMembersPath); err != nil {
+			return err
+		}
+	}
+	for _, memberID := range membersRemoveList {
+		err := groupsAPI.Patch(groupID, nil, []string{memberID}, GroupMembersPath)
+		if e, ok := err.(common.APIError); ok && e.IsMissing() {
+			log.Printf("[INFO] Member %s is already removed from group %s", memberID, groupID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupMembersList returns sorted member ids from a set, or nil if there are none
+func groupMembersList(v interface{}) (members []string) {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return
+	}
+	for _, member := range set.List() {
+		if member.(string) == "" {
+			continue
 		}
+		members = append(members, member.(string))
 	}
-	return false
+	sort.Strings(members)
+	return
 }
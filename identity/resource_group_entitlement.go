@@ -0,0 +1,41 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceGroupEntitlement defines group entitlement resource
+func ResourceGroupEntitlement() *schema.Resource {
+	return common.NewPairID("group_id", "entitlement").Schema(func(
+		m map[string]*schema.Schema) map[string]*schema.Schema {
+		// entitlements with allow_* arguments of databricks_group are managed only there,
+		// otherwise both resources would override each other on every apply
+		m["entitlement"].ValidateFunc = validation.StringInSlice([]string{
+			string(DatabricksSQLAccessEntitlement),
+		}, false)
+		return m
+	}).BindResource(common.BindResource{
+		ReadContext: func(ctx context.Context, groupID, entitlement string, c *common.DatabricksClient) error {
+			group, err := NewGroupsAPI(ctx, c).Read(groupID)
+			if err != nil {
+				return err
+			}
+			if !group.HasEntitlement(Entitlement(entitlement)) {
+				return common.NotFound("Group has no entitlement")
+			}
+			return nil
+		},
+		CreateContext: func(ctx context.Context, groupID, entitlement string, c *common.DatabricksClient) error {
+			return NewGroupsAPI(ctx, c).PatchR(groupID, scimPatchRequest("add", "entitlements", entitlement))
+		},
+		DeleteContext: func(ctx context.Context, groupID, entitlement string, c *common.DatabricksClient) error {
+			return NewGroupsAPI(ctx, c).PatchR(groupID, scimPatchRequest(
+				"remove", scimValueFilter("entitlements", entitlement), ""))
+		},
+	})
+}
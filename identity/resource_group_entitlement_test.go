@@ -0,0 +1,148 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGroupEntitlementCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest("add", "entitlements", "databricks-sql-access"),
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					Entitlements: []entitlementsListItem{
+						{DatabricksSQLAccessEntitlement},
+					},
+					ID: "abc",
+				},
+			},
+		},
+		Resource: ResourceGroupEntitlement(),
+		State: map[string]interface{}{
+			"group_id":    "abc",
+			"entitlement": "databricks-sql-access",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|databricks-sql-access", d.Id())
+}
+
+func TestResourceGroupEntitlementCreate_InvalidEntitlement(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceGroupEntitlement(),
+		State: map[string]interface{}{
+			"group_id":    "abc",
+			"entitlement": "allow-everything",
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [entitlement] expected entitlement to be one of")
+}
+
+func TestResourceGroupEntitlementCreate_GroupArgumentEntitlement(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceGroupEntitlement(),
+		State: map[string]interface{}{
+			"group_id":    "abc",
+			"entitlement": "allow-cluster-create",
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [entitlement] expected entitlement to be one of")
+}
+
+func TestResourceGroupEntitlementRead_WithGroupArguments(t *testing.T) {
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/preview/scim/v2/Groups/abc",
+			ReuseRequest: true,
+			Response: ScimGroup{
+				Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+				DisplayName: "Data Scientists",
+				Entitlements: []entitlementsListItem{
+					{AllowClusterCreateEntitlement},
+					{DatabricksSQLAccessEntitlement},
+				},
+				ID: "abc",
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceGroup(),
+		Read:     true,
+		ID:       "abc",
+		HCL: `
+		display_name = "Data Scientists"
+		allow_cluster_create = true`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("allow_cluster_create"))
+	assert.Equal(t, false, d.Get("allow_sql_analytics_access"))
+
+	d, err = qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceGroupEntitlement(),
+		Read:     true,
+		ID:       "abc|databricks-sql-access",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|databricks-sql-access", d.Id())
+}
+
+func TestResourceGroupEntitlementRead_RemovedExternally(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					Entitlements: []entitlementsListItem{
+						{AllowInstancePoolCreateEntitlement},
+					},
+					ID: "abc",
+				},
+			},
+		},
+		Resource: ResourceGroupEntitlement(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc|databricks-sql-access",
+	}.ApplyNoError(t)
+}
+
+func TestResourceGroupEntitlementDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"remove", `entitlements[value eq "databricks-sql-access"]`, ""),
+			},
+		},
+		Resource: ResourceGroupEntitlement(),
+		Delete:   true,
+		ID:       "abc|databricks-sql-access",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|databricks-sql-access", d.Id())
+}
@@ -3,17 +3,28 @@ package identity
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// groupMemberWaitTimeout bounds waiting for eventually consistent SCIM API
+// to reflect newly added group member
+const groupMemberWaitTimeout = 2 * time.Minute
+
 // ResourceGroupMember bind group with member
 func ResourceGroupMember() *schema.Resource {
 	return common.NewPairID("group_id", "member_id").BindResource(common.BindResource{
 		CreateContext: func(ctx context.Context, groupID, memberID string, c *common.DatabricksClient) error {
-			return NewGroupsAPI(ctx, c).PatchR(groupID, scimPatchRequest("add", "members", memberID))
+			groupsAPI := NewGroupsAPI(ctx, c)
+			err := groupsAPI.PatchR(groupID, scimPatchRequest("add", "members", memberID))
+			if err != nil {
+				return err
+			}
+			return waitForGroupMember(ctx, groupsAPI, groupID, memberID)
 		},
 		ReadContext: func(ctx context.Context, groupID, memberID string, c *common.DatabricksClient) error {
 			group, err := NewGroupsAPI(ctx, c).Read(groupID)
@@ -24,7 +35,23 @@ func ResourceGroupMember() *schema.Resource {
 		},
 		DeleteContext: func(ctx context.Context, groupID, memberID string, c *common.DatabricksClient) error {
 			return NewGroupsAPI(ctx, c).PatchR(groupID, scimPatchRequest(
-				"remove", fmt.Sprintf(`members[value eq "%s"]`, memberID), ""))
+				"remove", scimValueFilter("members", memberID), ""))
 		},
 	})
 }
+
+// waitForGroupMember re-reads group with backoff until it has the member, as
+// SCIM API is eventually consistent and read right after patch may not have it
+func waitForGroupMember(ctx context.Context, groupsAPI GroupsAPI, groupID, memberID string) error {
+	return resource.RetryContext(ctx, groupMemberWaitTimeout, func() *resource.RetryError {
+		group, err := groupsAPI.Read(groupID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !group.HasMember(memberID) {
+			return resource.RetryableError(fmt.Errorf(
+				"Group %s has no member %s yet", groupID, memberID))
+		}
+		return nil
+	})
+}
@@ -10,6 +10,44 @@ import (
 )
 
 func TestResourceGroupMemberCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest("add", "members", "bcd"),
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/preview/scim/v2/Groups/abc",
+				ReuseRequest: true,
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					Members: []GroupMember{
+						{
+							Value: "bcd",
+						},
+					},
+					ID: "abc",
+				},
+			},
+		},
+		Resource: ResourceGroupMember(),
+		State: map[string]interface{}{
+			"group_id":  "abc",
+			"member_id": "bcd",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|bcd", d.Id())
+}
+
+func TestResourceGroupMemberCreate_EventuallyConsistent(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
@@ -24,7 +62,15 @@ func TestResourceGroupMemberCreate(t *testing.T) {
 				Method:   "GET",
 				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
 				Response: ScimGroup{
-					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/preview/scim/v2/Groups/abc",
+				ReuseRequest: true,
+				Response: ScimGroup{
 					DisplayName: "Data Scientists",
 					Members: []GroupMember{
 						{
@@ -177,6 +223,81 @@ func TestResourceGroupMemberDelete(t *testing.T) {
 	assert.Equal(t, "abc|bcd", d.Id())
 }
 
+func TestResourceGroupMemberDelete_EscapesFilterValue(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"remove",
+					`members[value eq "b\" or value pr or value eq \"c"]`,
+					""),
+			},
+		},
+		Resource: ResourceGroupMember(),
+		Delete:   true,
+		ID:       `abc|b" or value pr or value eq "c`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, `abc|b" or value pr or value eq "c`, d.Id())
+}
+
+func TestScimValueFilter(t *testing.T) {
+	assert.Equal(t, `roles[value eq "arn"]`, scimValueFilter("roles", "arn"))
+	assert.Equal(t, `members[value eq "a\"b"]`, scimValueFilter("members", `a"b`))
+	assert.Equal(t, `members[value eq "a\\"]`, scimValueFilter("members", `a\`))
+}
+
+func TestScimEqFilter(t *testing.T) {
+	assert.Equal(t, `userName eq 'me@example.com'`, scimEqFilter("userName", "me@example.com"))
+	assert.Equal(t, `displayName eq 'a\' or displayName eq \'b'`,
+		scimEqFilter("displayName", `a' or displayName eq 'b`))
+	assert.Equal(t, `displayName eq 'a\\'`, scimEqFilter("displayName", `a\`))
+}
+
+func TestResourceGroupMemberCreate_PatchPayload(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedPartialRequest: map[string]interface{}{
+					"Operations": []interface{}{
+						map[string]interface{}{
+							"op":   "add",
+							"path": "members",
+							"value": []interface{}{
+								map[string]string{"value": "bcd"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/preview/scim/v2/Groups/abc",
+				ReuseRequest: true,
+				Response: ScimGroup{
+					ID: "abc",
+					Members: []GroupMember{
+						{
+							Value: "bcd",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroupMember(),
+		State: map[string]interface{}{
+			"group_id":  "abc",
+			"member_id": "bcd",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
 func TestResourceGroupMemberDelete_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
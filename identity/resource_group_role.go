@@ -0,0 +1,66 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// gcpServiceAccountEmail matches user-managed service accounts of a project, as well as
+// the default Compute Engine service account, that is named after the project number
+var gcpServiceAccountEmail = regexp.MustCompile(
+	`^([a-z][a-z0-9-]{4,28}[a-z0-9]@[a-z][a-z0-9-]{4,28}[a-z0-9]\.iam|[0-9]+-compute@developer)\.gserviceaccount\.com$`)
+
+// ValidGroupRole checks that role is either AWS instance profile ARN or an email of GCP service account
+func ValidGroupRole(v interface{}, c cty.Path) diag.Diagnostics {
+	s, ok := v.(string)
+	if !ok || !strings.Contains(s, "@") {
+		return ValidInstanceProfile(v, c)
+	}
+	if !gcpServiceAccountEmail.MatchString(s) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				AttributePath: c,
+				Summary:       "Invalid role",
+				Detail:        fmt.Sprintf("Not a GCP service account email: %s", s),
+			},
+		}
+	}
+	return nil
+}
+
+// ResourceGroupRole binds AWS instance profile or GCP service account role to a group
+func ResourceGroupRole() *schema.Resource {
+	return groupRoleResource("role", ValidGroupRole, "Group has no role")
+}
+
+// groupRoleResource manages a single value within roles of a group, that are otherwise
+// reconciled by databricks_group resource
+func groupRoleResource(key string, validate schema.SchemaValidateDiagFunc, missing string) *schema.Resource {
+	return common.NewPairID("group_id", key).Schema(func(
+		m map[string]*schema.Schema) map[string]*schema.Schema {
+		m[key].ValidateDiagFunc = validate
+		return m
+	}).BindResource(common.BindResource{
+		ReadContext: func(ctx context.Context, groupID, role string, c *common.DatabricksClient) error {
+			group, err := NewGroupsAPI(ctx, c).Read(groupID)
+			if err == nil && !group.HasRole(role) {
+				return common.NotFound(missing)
+			}
+			return err
+		},
+		CreateContext: func(ctx context.Context, groupID, role string, c *common.DatabricksClient) error {
+			return NewGroupsAPI(ctx, c).Patch(groupID, []string{role}, nil, GroupRolesPath)
+		},
+		DeleteContext: func(ctx context.Context, groupID, role string, c *common.DatabricksClient) error {
+			return NewGroupsAPI(ctx, c).Patch(groupID, nil, []string{role}, GroupRolesPath)
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGroupRoleCreate_GcpServiceAccount(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"add",
+					"roles",
+					"data-access@my-project.iam.gserviceaccount.com"),
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					Roles: []roleListItem{
+						{"data-access@my-project.iam.gserviceaccount.com"},
+					},
+					ID: "abc",
+				},
+			},
+		},
+		Resource: ResourceGroupRole(),
+		State: map[string]interface{}{
+			"group_id": "abc",
+			"role":     "data-access@my-project.iam.gserviceaccount.com",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|data-access@my-project.iam.gserviceaccount.com", d.Id())
+}
+
+func TestResourceGroupRoleCreate_InstanceProfile(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"add",
+					"roles",
+					"arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					ID: "abc",
+					Roles: []roleListItem{
+						{"arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroupRole(),
+		State: map[string]interface{}{
+			"group_id": "abc",
+			"role":     "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile", d.Id())
+}
+
+func TestResourceGroupRoleCreate_InvalidServiceAccount(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGroupRole(),
+		State: map[string]interface{}{
+			"group_id": "abc",
+			"role":     "someone@example.com",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [role] Invalid role")
+}
+
+func TestValidGroupRole(t *testing.T) {
+	for _, role := range []string{
+		"data-access@my-project.iam.gserviceaccount.com",
+		"123456789012-compute@developer.gserviceaccount.com",
+		"arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile",
+	} {
+		assert.Nil(t, ValidGroupRole(role, cty.Path{}), role)
+	}
+	for _, role := range []string{
+		"someone@example.com",
+		"my-project-compute@developer.gserviceaccount.com",
+		"123456789012-compute@developer.gserviceaccount.com.evil",
+	} {
+		assert.NotNil(t, ValidGroupRole(role, cty.Path{}), role)
+	}
+}
+
+func TestResourceGroupRoleRead_Removed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					ID: "abc",
+					Roles: []roleListItem{
+						{"other@my-project.iam.gserviceaccount.com"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroupRole(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc|data-access@my-project.iam.gserviceaccount.com",
+	}.ApplyNoError(t)
+}
+
+func TestResourceGroupRoleDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"remove",
+					`roles[value eq "data-access@my-project.iam.gserviceaccount.com"]`,
+					""),
+			},
+		},
+		Resource: ResourceGroupRole(),
+		Delete:   true,
+		ID:       "abc|data-access@my-project.iam.gserviceaccount.com",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|data-access@my-project.iam.gserviceaccount.com", d.Id())
+}
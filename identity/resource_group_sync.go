@@ -0,0 +1,158 @@
+package identity
+
+import (
+	"context"
+	"log"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// groupSyncPatchRequest returns a single SCIM patch request, that adds and removes
+// given member ids, or nil if there's nothing to change
+func groupSyncPatchRequest(add, remove []string) *patchRequest {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	r := patchRequest{
+		Schemas: []URN{PatchOp},
+	}
+	if len(add) > 0 {
+		values := []ValueListItem{}
+		for _, memberID := range add {
+			values = append(values, ValueListItem{memberID})
+		}
+		r.Operations = append(r.Operations, patchOperation{
+			Op:    "add",
+			Path:  string(GroupMembersPath),
+			Value: values,
+		})
+	}
+	for _, memberID := range remove {
+		r.Operations = append(r.Operations, patchOperation{
+			Op:   "remove",
+			Path: scimValueFilter(string(GroupMembersPath), memberID),
+		})
+	}
+	return &r
+}
+
+// groupSyncDiff returns members to add to and remove from the group, so that it has
+// all desired members. Members listed in previous apply and not desired anymore are
+// removed, as well as all other members of the group, if sync is exclusive.
+func groupSyncDiff(group ScimGroup, previous, desired *schema.Set, exclusive bool) (add, remove []string) {
+	for _, memberID := range groupMembersList(desired) {
+		if !group.HasMember(memberID) {
+			add = append(add, memberID)
+		}
+	}
+	if exclusive {
+		for _, member := range group.Members {
+			if !desired.Contains(member.Value) {
+				remove = append(remove, member.Value)
+			}
+		}
+		return
+	}
+	for _, memberID := range groupMembersList(previous.Difference(desired)) {
+		if group.HasMember(memberID) {
+			remove = append(remove, memberID)
+		}
+	}
+	return
+}
+
+func syncGroupMembers(ctx context.Context, d *schema.ResourceData,
+	c *common.DatabricksClient, previous *schema.Set) error {
+	groupsAPI := NewGroupsAPI(ctx, c)
+	groupID := d.Get("group_id").(string)
+	group, err := groupsAPI.Read(groupID)
+	if err != nil {
+		return err
+	}
+	add, remove := groupSyncDiff(group, previous,
+		d.Get("members").(*schema.Set), d.Get("exclusive").(bool))
+	r := groupSyncPatchRequest(add, remove)
+	if r == nil {
+		return nil
+	}
+	log.Printf("[INFO] Syncing members of group %s: adding %v, removing %v", groupID, add, remove)
+	return groupsAPI.PatchR(groupID, *r)
+}
+
+// ResourceGroupSync converges membership of a group to an authoritative list of members
+func ResourceGroupSync() *schema.Resource {
+	return common.Resource{
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			err := syncGroupMembers(ctx, d, c, &schema.Set{F: schema.HashString})
+			if err != nil {
+				return err
+			}
+			d.SetId(d.Get("group_id").(string))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			group, err := NewGroupsAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			members := []interface{}{}
+			if d.Get("exclusive").(bool) {
+				// any other member of the group is a drift
+				for _, member := range group.Members {
+					members = append(members, member.Value)
+				}
+			} else {
+				for _, memberID := range groupMembersList(d.Get("members")) {
+					if group.HasMember(memberID) {
+						members = append(members, memberID)
+					}
+				}
+			}
+			if err = d.Set("group_id", d.Id()); err != nil {
+				return err
+			}
+			return d.Set("members", members)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			previous, _ := d.GetChange("members")
+			return syncGroupMembers(ctx, d, c, previous.(*schema.Set))
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			groupsAPI := NewGroupsAPI(ctx, c)
+			group, err := groupsAPI.Read(d.Id())
+			if err != nil {
+				return err
+			}
+			var remove []string
+			for _, memberID := range groupMembersList(d.Get("members")) {
+				if group.HasMember(memberID) {
+					remove = append(remove, memberID)
+				}
+			}
+			r := groupSyncPatchRequest(nil, remove)
+			if r == nil {
+				return nil
+			}
+			return groupsAPI.PatchR(d.Id(), *r)
+		},
+	}.ToResource()
+}
@@ -0,0 +1,183 @@
+package identity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupWithMembers(members ...string) ScimGroup {
+	group := ScimGroup{
+		ID:          "abc",
+		DisplayName: "Data Scientists",
+	}
+	for _, member := range members {
+		group.Members = append(group.Members, GroupMember{Value: member})
+	}
+	return group
+}
+
+func TestResourceGroupSyncCreate_AdditionsOnly(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: groupSyncPatchRequest([]string{"b", "c"}, nil),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "b", "c", "x"),
+			},
+		},
+		Resource: ResourceGroupSync(),
+		HCL: `
+		group_id = "abc"
+		members = ["a", "b", "c"]`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 3, members.Len())
+	assert.False(t, members.Contains("x"), "non-exclusive sync keeps other members")
+}
+
+func TestResourceGroupSyncUpdate_RemovalsOnly(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "b", "c", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: groupSyncPatchRequest(nil, []string{"b", "c"}),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "x"),
+			},
+		},
+		Resource: ResourceGroupSync(),
+		InstanceState: map[string]string{
+			"group_id":  "abc",
+			"exclusive": "false",
+			"members.#": "3",
+			fmt.Sprintf("members.%d", schema.HashString("a")): "a",
+			fmt.Sprintf("members.%d", schema.HashString("b")): "b",
+			fmt.Sprintf("members.%d", schema.HashString("c")): "c",
+		},
+		HCL: `
+		group_id = "abc"
+		members = ["a"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 1, members.Len())
+	assert.True(t, members.Contains("a"))
+}
+
+func TestResourceGroupSyncUpdate_ExclusiveFullReconcile(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "b", "x", "y"),
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: groupSyncPatchRequest([]string{"c"},
+					[]string{"b", "x", "y"}),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "c"),
+			},
+		},
+		Resource: ResourceGroupSync(),
+		InstanceState: map[string]string{
+			"group_id":  "abc",
+			"exclusive": "false",
+			"members.#": "2",
+			fmt.Sprintf("members.%d", schema.HashString("a")): "a",
+			fmt.Sprintf("members.%d", schema.HashString("b")): "b",
+		},
+		HCL: `
+		group_id = "abc"
+		members = ["a", "c"]
+		exclusive = true`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 2, members.Len())
+	assert.True(t, members.Contains("c"))
+}
+
+func TestResourceGroupSyncRead_ExclusiveDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "x"),
+			},
+		},
+		Resource: ResourceGroupSync(),
+		State: map[string]interface{}{
+			"group_id":  "abc",
+			"members":   []interface{}{"a", "b"},
+			"exclusive": true,
+		},
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.True(t, members.Contains("x"))
+	assert.False(t, members.Contains("b"))
+}
+
+func TestResourceGroupSyncDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: groupWithMembers("a", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: groupSyncPatchRequest(nil, []string{"a"}),
+			},
+		},
+		Resource: ResourceGroupSync(),
+		State: map[string]interface{}{
+			"group_id": "abc",
+			"members":  []interface{}{"a", "b"},
+		},
+		Delete: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
@@ -1,11 +1,13 @@
 package identity
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +45,51 @@ func TestResourceGroupCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceGroupCreate_WithMembers(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				ExpectedRequest: map[string]interface{}{
+					"schemas":     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					"displayName": "Data Scientists",
+					"members": []ValueListItem{
+						{Value: "123"},
+						{Value: "456"},
+						{Value: "789"},
+					},
+				},
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Members: []GroupMember{
+						{Value: "123"},
+						{Value: "456"},
+						{Value: "789"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["456", "123", "789"]`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 3, d.Get("members.#"))
+}
+
 func TestResourceGroupCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -66,6 +113,188 @@ func TestResourceGroupCreate_Error(t *testing.T) {
 	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
 }
 
+func groupConflictFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Groups",
+			Response: common.APIErrorBody{
+				ScimDetail: "Group with name Data Scientists already exists.",
+				ScimStatus: "409",
+			},
+			Status: 409,
+		},
+	}
+}
+
+func TestResourceGroupCreate_ConflictWithoutForce(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: groupConflictFixtures(),
+		Resource: ResourceGroup(),
+		State: map[string]interface{}{
+			"display_name": "Data Scientists",
+		},
+		Create: true,
+	}.ExpectError(t, "Group with name Data Scientists already exists.")
+}
+
+func TestResourceGroupCreate_AdoptOnConflict(t *testing.T) {
+	existing := ScimGroup{
+		Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+		DisplayName: "Data Scientists",
+		ID:          "abc",
+		Members: []GroupMember{
+			{Value: "123"},
+			{Value: "from-idp"},
+		},
+		Entitlements: []entitlementsListItem{
+			{
+				Value: AllowInstancePoolCreateEntitlement,
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: append(groupConflictFixtures(),
+			qa.HTTPFixture{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%27Data%20Scientists%27",
+				Response: GroupList{
+					Resources: []ScimGroup{existing},
+				},
+			},
+			qa.HTTPFixture{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:    "add",
+							Path:  "members",
+							Value: []ValueListItem{{Value: "456"}},
+						},
+					},
+				},
+			},
+			qa.HTTPFixture{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:    "add",
+							Path:  "entitlements",
+							Value: []ValueListItem{{Value: "allow-cluster-create"}},
+						},
+						{
+							Op:   "remove",
+							Path: `entitlements[value eq "allow-instance-pool-create"]`,
+						},
+					},
+				},
+			},
+			qa.HTTPFixture{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: AllowClusterCreateEntitlement,
+						},
+					},
+				},
+			}),
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		allow_cluster_create = true
+		members = ["123", "456"]
+		force = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, true, d.Get("adopted"))
+	assert.Equal(t, true, d.Get("allow_cluster_create"))
+	assert.Equal(t, false, d.Get("allow_instance_pool_create"))
+}
+
+func TestResourceGroupCreate_ForceWithoutConflict(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		force = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, false, d.Get("adopted"))
+}
+
+func TestResourceGroupDelete_Adopted(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"adopted":      "true",
+		},
+		HCL:    `display_name = "Data Scientists"`,
+		Delete: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceGroupDelete_AdoptedWithForceDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"adopted":      "true",
+			"force_delete": "true",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		force_delete = true
+		`,
+		Delete: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
 func TestResourceGroupRead(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -90,6 +319,37 @@ func TestResourceGroupRead(t *testing.T) {
 	assert.Equal(t, "Data Scientists", d.Get("display_name"))
 }
 
+func TestResourceGroupRead_Entitlements(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: AllowInstancePoolCreateEntitlement,
+						},
+						{
+							Value: AllowSQLAnalyticsAccessEntitlement,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		Read:     true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
+	assert.Equal(t, true, d.Get("allow_sql_analytics_access"))
+}
+
 func TestResourceGroupRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -137,7 +397,7 @@ func TestResourceGroupUpdate(t *testing.T) {
 			{
 				Method:   "PATCH",
 				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
-				Response: GroupPatchRequest{
+				ExpectedRequest: GroupPatchRequest{
 					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
 					Operations: []GroupPatchOperations{
 						{
@@ -145,13 +405,13 @@ func TestResourceGroupUpdate(t *testing.T) {
 							Path: "entitlements",
 							Value: []ValueListItem{
 								{
-									Value: "allow-cluster-create",
+									Value: "allow-instance-pool-create",
 								},
 							},
 						},
 						{
 							Op:   "remove",
-							Path: "entitlements[value eq \"allow-cluster-create\"]",
+							Path: "entitlements[value eq \"allow-instance-pool-create\"]",
 						},
 					},
 				},
@@ -178,6 +438,185 @@ func TestResourceGroupUpdate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
 }
 
+func TestResourceGroupUpdate_Members(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "add",
+							Path: "members",
+							Value: []ValueListItem{
+								{
+									Value: "789",
+								},
+							},
+						},
+						{
+							Op:   "remove",
+							Path: "members[value eq \"123\"]",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"members.#":    "2",
+			fmt.Sprintf("members.%d", schema.HashString("123")): "123",
+			fmt.Sprintf("members.%d", schema.HashString("456")): "456",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["456", "789"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func removeMemberRequest(memberID string) GroupPatchRequest {
+	return GroupPatchRequest{
+		Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		Operations: []GroupPatchOperations{
+			{
+				Op:   "remove",
+				Path: GroupPathType(fmt.Sprintf("members[value eq \"%s\"]", memberID)),
+			},
+		},
+	}
+}
+
+func TestResourceGroupUpdate_MembersAlreadyRemoved(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "remove",
+							Path: "members[value eq \"123\"]",
+						},
+						{
+							Op:   "remove",
+							Path: "members[value eq \"456\"]",
+						},
+					},
+				},
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Member 123 not found",
+				},
+				Status: 404,
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: removeMemberRequest("123"),
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Member 123 not found",
+				},
+				Status: 404,
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: removeMemberRequest("456"),
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"members.#":    "3",
+			fmt.Sprintf("members.%d", schema.HashString("123")): "123",
+			fmt.Sprintf("members.%d", schema.HashString("456")): "456",
+			fmt.Sprintf("members.%d", schema.HashString("789")): "789",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["789"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.True(t, members.Contains("789"))
+	assert.False(t, members.Contains("123"))
+	assert.False(t, members.Contains("456"))
+}
+
+func TestResourceGroupUpdate_MembersPartialFailure(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Member 123 not found",
+				},
+				Status: 404,
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: removeMemberRequest("123"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: removeMemberRequest("456"),
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Cannot remove member",
+				},
+				Status: 400,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Members: []GroupMember{
+						{Value: "456"},
+						{Value: "789"},
+						{Value: "unmanaged"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"members.#":    "3",
+			fmt.Sprintf("members.%d", schema.HashString("123")): "123",
+			fmt.Sprintf("members.%d", schema.HashString("456")): "456",
+			fmt.Sprintf("members.%d", schema.HashString("789")): "789",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["789"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot remove member")
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 2, members.Len())
+	assert.True(t, members.Contains("456"))
+	assert.True(t, members.Contains("789"))
+}
+
 func TestResourceGroupUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -239,3 +678,404 @@ func TestResourceGroupDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceGroupCreate_WithRoles(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				ExpectedRequest: map[string]interface{}{
+					"schemas":     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					"displayName": "Data Scientists",
+					"members": []ValueListItem{
+						{Value: "123"},
+					},
+					"roles": []ValueListItem{
+						{Value: "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"},
+					},
+				},
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Members: []GroupMember{
+						{Value: "123"},
+					},
+					Roles: []roleListItem{
+						{Value: "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["123"]
+		roles = ["arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"]`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 1, d.Get("members.#"))
+	assert.Equal(t, 1, d.Get("roles.#"))
+}
+
+func groupWithMembersFixture(members ...string) qa.HTTPFixture {
+	group := ScimGroup{
+		Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+		DisplayName: "Data Scientists",
+		ID:          "abc",
+	}
+	for _, member := range members {
+		group.Members = append(group.Members, GroupMember{Value: member})
+	}
+	return qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+		Response: group,
+	}
+}
+
+func TestResourceGroupRead_MembersDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			groupWithMembersFixture("456", "789"),
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"members.#":    "2",
+			fmt.Sprintf("members.%d", schema.HashString("123")): "123",
+			fmt.Sprintf("members.%d", schema.HashString("456")): "456",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		members = ["123", "456"]`,
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 2, members.Len())
+	assert.True(t, members.Contains("456"))
+	assert.True(t, members.Contains("789"))
+}
+
+func TestResourceGroupRead_ExternalMembers(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			groupWithMembersFixture("456", "789"),
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name":     "Data Scientists",
+			"external_members": "true",
+			"members.#":        "2",
+			fmt.Sprintf("members.%d", schema.HashString("123")): "123",
+			fmt.Sprintf("members.%d", schema.HashString("456")): "456",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		external_members = true
+		members = ["123", "456"]`,
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	members := d.Get("members").(*schema.Set)
+	assert.Equal(t, 1, members.Len(), "789 is added outside of Terraform")
+	assert.True(t, members.Contains("456"))
+}
+
+func TestResourceGroupRead_MembersManagedElsewhere(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			groupWithMembersFixture("456", "789"),
+		},
+		Resource: ResourceGroup(),
+		Read:     true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("members.#"))
+}
+
+func TestResourceGroupUpdate_Roles(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "add",
+							Path: "roles",
+							Value: []ValueListItem{
+								{
+									Value: "arn:aws:iam::999999999999:instance-profile/b",
+								},
+							},
+						},
+						{
+							Op:   "remove",
+							Path: "roles[value eq \"arn:aws:iam::999999999999:instance-profile/a\"]",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name": "Data Scientists",
+			"roles.#":      "1",
+			fmt.Sprintf("roles.%d", schema.HashString("arn:aws:iam::999999999999:instance-profile/a")): "arn:aws:iam::999999999999:instance-profile/a",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		roles = ["arn:aws:iam::999999999999:instance-profile/b"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceGroupUpdate_EntitlementRemoved(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "remove",
+							Path: "entitlements[value eq \"allow-cluster-create\"]",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name":         "Data Scientists",
+			"allow_cluster_create": "true",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		allow_cluster_create = false`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+}
+
+func TestResourceGroupCreate_WithEntitlements(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				ExpectedRequest: map[string]interface{}{
+					"schemas":     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					"displayName": "Data Scientists",
+					"entitlements": []ValueListItem{
+						{Value: "allow-cluster-create"},
+						{Value: "databricks-sql-access"},
+					},
+				},
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{AllowClusterCreateEntitlement},
+						{DatabricksSQLAccessEntitlement},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		entitlements = ["allow-cluster-create", "databricks-sql-access"]`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 2, d.Get("entitlements.#"))
+	assert.Equal(t, false, d.Get("allow_cluster_create"),
+		"flags are not set, when entitlements are managed inline")
+}
+
+func TestResourceGroupCreate_EntitlementsConflictWithFlags(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		allow_cluster_create = true
+		entitlements = ["databricks-sql-access"]`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. allow_cluster_create: conflicts with entitlements")
+}
+
+func TestResourceGroupRead_EntitlementsDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{AllowInstancePoolCreateEntitlement},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name":   "Data Scientists",
+			"entitlements.#": "1",
+			fmt.Sprintf("entitlements.%d", schema.HashString("allow-cluster-create")): "allow-cluster-create",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		entitlements = ["allow-cluster-create"]`,
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	entitlements := d.Get("entitlements").(*schema.Set)
+	assert.Equal(t, 1, entitlements.Len())
+	assert.True(t, entitlements.Contains("allow-instance-pool-create"))
+	assert.Equal(t, false, d.Get("allow_instance_pool_create"))
+}
+
+func TestResourceGroupUpdate_Entitlements(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "add",
+							Path: "entitlements",
+							Value: []ValueListItem{
+								{
+									Value: "databricks-sql-access",
+								},
+							},
+						},
+						{
+							Op:   "remove",
+							Path: "entitlements[value eq \"allow-cluster-create\"]",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		InstanceState: map[string]string{
+			"display_name":   "Data Scientists",
+			"entitlements.#": "1",
+			fmt.Sprintf("entitlements.%d", schema.HashString("allow-cluster-create")): "allow-cluster-create",
+		},
+		HCL: `
+		display_name = "Data Scientists"
+		entitlements = ["databricks-sql-access"]`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceGroupCreate_AdoptWithEntitlements(t *testing.T) {
+	existing := ScimGroup{
+		Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+		DisplayName: "Data Scientists",
+		ID:          "abc",
+		Entitlements: []entitlementsListItem{
+			{
+				Value: DatabricksSQLAccessEntitlement,
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: append(groupConflictFixtures(),
+			qa.HTTPFixture{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%27Data%20Scientists%27",
+				Response: GroupList{
+					Resources: []ScimGroup{existing},
+				},
+			},
+			qa.HTTPFixture{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+					Operations: []GroupPatchOperations{
+						{
+							Op:    "add",
+							Path:  "entitlements",
+							Value: []ValueListItem{{Value: "allow-instance-pool-create"}},
+						},
+						{
+							Op:   "remove",
+							Path: `entitlements[value eq "databricks-sql-access"]`,
+						},
+					},
+				},
+			},
+			qa.HTTPFixture{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: AllowInstancePoolCreateEntitlement,
+						},
+					},
+				},
+			}),
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		entitlements = ["allow-instance-pool-create"]
+		force = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("adopted"))
+	assert.Equal(t, 1, d.Get("entitlements.#"))
+}
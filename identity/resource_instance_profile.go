@@ -41,11 +41,12 @@ type InstanceProfilesAPI struct {
 	context context.Context
 }
 
-// Create creates an instance profile record on Databricks
-func (a InstanceProfilesAPI) Create(instanceProfileARN string) error {
+// Create creates an instance profile record on Databricks. Workspace checks, that
+// the instance profile could be used to launch clusters, unless skipValidation is true.
+func (a InstanceProfilesAPI) Create(instanceProfileARN string, skipValidation bool) error {
 	return a.client.Post(a.context, "/instance-profiles/add", map[string]interface{}{
 		"instance_profile_arn": instanceProfileARN,
-		"skip_validation":      false,
+		"skip_validation":      skipValidation,
 	}, nil)
 }
 
@@ -146,6 +147,11 @@ func ResourceInstanceProfile() *schema.Resource {
 
 				ValidateDiagFunc: ValidInstanceProfile,
 			},
+			"skip_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			profile, err := NewInstanceProfilesAPI(ctx, c).Read(d.Id())
@@ -156,7 +162,8 @@ func ResourceInstanceProfile() *schema.Resource {
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			ipa := d.Get("instance_profile_arn").(string)
-			if err := NewInstanceProfilesAPI(ctx, c).Create(ipa); err != nil {
+			skipValidation := d.Get("skip_validation").(bool)
+			if err := NewInstanceProfilesAPI(ctx, c).Create(ipa, skipValidation); err != nil {
 				return err
 			}
 			d.SetId(ipa)
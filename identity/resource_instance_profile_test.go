@@ -43,6 +43,40 @@ func TestResourceInstanceProfileCreate(t *testing.T) {
 	assert.Equal(t, "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile", d.Id())
 }
 
+func TestResourceInstanceProfileCreate_SkipValidation(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-profiles/add",
+				ExpectedRequest: map[string]interface{}{
+					"instance_profile_arn": "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile",
+					"skip_validation":      true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-profiles/list",
+				Response: InstanceProfileList{
+					InstanceProfiles: []InstanceProfileInfo{
+						{
+							InstanceProfileArn: "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceInstanceProfile(),
+		HCL: `
+		instance_profile_arn = "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"
+		skip_validation = true`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile", d.Id())
+	assert.Equal(t, true, d.Get("skip_validation"))
+}
+
 func TestResourceInstanceProfileCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -186,7 +220,7 @@ func TestAwsAccInstanceProfiles(t *testing.T) {
 	ctx := context.WithValue(context.Background(), common.Current, t.Name())
 	instanceProfilesAPI := NewInstanceProfilesAPI(ctx, client)
 	instanceProfilesAPI.Synchronized(arn, func() bool {
-		err := instanceProfilesAPI.Create(arn)
+		err := instanceProfilesAPI.Create(arn, false)
 		if err != nil {
 			return false
 		}
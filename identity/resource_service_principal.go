@@ -52,7 +52,7 @@ func (sp ServicePrincipalEntity) toRequest() ScimUser {
 
 // CreateR ..
 func (a ServicePrincipalsAPI) CreateR(rsp ServicePrincipalEntity) (sp ScimUser, err error) {
-	err = a.client.Scim(a.context, "POST", "/preview/scim/v2/ServicePrincipals", rsp.toRequest(), &sp)
+	err = a.client.Scim(a.context, "POST", "/ServicePrincipals", rsp.toRequest(), &sp)
 	return sp, err
 }
 
@@ -77,7 +77,7 @@ func (a ServicePrincipalsAPI) ReadR(servicePrincipalID string) (rsp ServicePrinc
 }
 
 func (a ServicePrincipalsAPI) read(servicePrincipalID string) (sp ScimUser, err error) {
-	servicePrincipalPath := fmt.Sprintf("/preview/scim/v2/ServicePrincipals/%v", servicePrincipalID)
+	servicePrincipalPath := fmt.Sprintf("/ServicePrincipals/%v", servicePrincipalID)
 	err = a.client.Scim(a.context, "GET", servicePrincipalPath, nil, &sp)
 	return
 }
@@ -91,13 +91,13 @@ func (a ServicePrincipalsAPI) UpdateR(servicePrincipalID string, rsp ServicePrin
 	updateRequest := rsp.toRequest()
 	updateRequest.Groups = servicePrincipal.Groups
 	return a.client.Scim(a.context, "PUT",
-		fmt.Sprintf("/preview/scim/v2/ServicePrincipals/%v", servicePrincipalID),
+		fmt.Sprintf("/ServicePrincipals/%v", servicePrincipalID),
 		updateRequest, nil)
 }
 
 // Delete will delete the servicePrincipal given the servicePrincipal id
 func (a ServicePrincipalsAPI) Delete(servicePrincipalID string) error {
-	servicePrincipalPath := fmt.Sprintf("/preview/scim/v2/ServicePrincipals/%v", servicePrincipalID)
+	servicePrincipalPath := fmt.Sprintf("/ServicePrincipals/%v", servicePrincipalID)
 	return a.client.Scim(a.context, "DELETE", servicePrincipalPath, nil, nil)
 }
 
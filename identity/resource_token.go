@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -151,7 +152,12 @@ func ResourceToken() *schema.Resource {
 			return common.StructToData(tokenInfo, s, d)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewTokensAPI(ctx, c).Delete(d.Id())
+			err := NewTokensAPI(ctx, c).Delete(d.Id())
+			if e, ok := err.(common.APIError); ok && e.IsMissing() {
+				log.Printf("[INFO] Token %s is already revoked", d.Id())
+				return nil
+			}
+			return err
 		},
 	}.ToResource()
 }
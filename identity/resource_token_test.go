@@ -218,6 +218,25 @@ func TestResourceTokenDelete(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceTokenDelete_AlreadyRevoked(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Token abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceToken(),
+		Delete:   true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
 func TestResourceTokenDelete_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -2,6 +2,7 @@ package identity
 
 import (
 	"context"
+	"log"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -14,6 +15,18 @@ func ResourceUser() *schema.Resource {
 		s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["user_name"].ForceNew = true
 		s["active"].Default = true
+		// adopt user with the same user_name instead of failing on create
+		s["allow_existing"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+		// keep the user deactivated instead of deleting it
+		s["deactivate_on_delete"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
 		return s
 	})
 	return common.Resource{
@@ -23,7 +36,22 @@ func ResourceUser() *schema.Resource {
 			if err := common.DataToStructPointer(d, userSchema, &ru); err != nil {
 				return err
 			}
-			user, err := NewUsersAPI(ctx, c).Create(ru)
+			usersAPI := NewUsersAPI(ctx, c)
+			if d.Get("allow_existing").(bool) {
+				existing, found, err := usersAPI.ReadByUserName(ru.UserName)
+				if err != nil {
+					return err
+				}
+				if found {
+					log.Printf("[INFO] Adopting existing user %s (%s)", ru.UserName, existing.ID)
+					if err = usersAPI.Update(existing.ID, ru); err != nil {
+						return err
+					}
+					d.SetId(existing.ID)
+					return nil
+				}
+			}
+			user, err := usersAPI.Create(ru)
 			if err != nil {
 				return err
 			}
@@ -45,7 +73,11 @@ func ResourceUser() *schema.Resource {
 			return NewUsersAPI(ctx, c).Update(d.Id(), ru)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewUsersAPI(ctx, c).Delete(d.Id())
+			usersAPI := NewUsersAPI(ctx, c)
+			if d.Get("deactivate_on_delete").(bool) {
+				return usersAPI.Deactivate(d.Id())
+			}
+			return usersAPI.Delete(d.Id())
 		},
 	}.ToResource()
 }
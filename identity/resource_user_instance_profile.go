@@ -2,7 +2,6 @@ package identity
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -28,7 +27,7 @@ func ResourceUserInstanceProfile() *schema.Resource {
 		},
 		DeleteContext: func(ctx context.Context, userID, roleARN string, c *common.DatabricksClient) error {
 			return NewUsersAPI(ctx, c).Patch(userID, scimPatchRequest(
-				"remove", fmt.Sprintf(`roles[value eq "%s"]`, roleARN), ""))
+				"remove", scimValueFilter("roles", roleARN), ""))
 		},
 	})
 }
@@ -0,0 +1,37 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceUserManager binds user with its manager through SCIM enterprise extension
+func ResourceUserManager() *schema.Resource {
+	return common.NewPairID("user_id", "manager_id").BindResource(common.BindResource{
+		CreateContext: func(ctx context.Context, userID, managerID string, c *common.DatabricksClient) error {
+			return NewUsersAPI(ctx, c).SetManager(userID, managerID)
+		},
+		ReadContext: func(ctx context.Context, userID, managerID string, c *common.DatabricksClient) error {
+			user, err := NewUsersAPI(ctx, c).read(userID)
+			if err == nil && !user.HasManager(managerID) {
+				return common.NotFound("User has no such manager")
+			}
+			return err
+		},
+		DeleteContext: func(ctx context.Context, userID, managerID string, c *common.DatabricksClient) error {
+			usersAPI := NewUsersAPI(ctx, c)
+			user, err := usersAPI.read(userID)
+			if err != nil {
+				return err
+			}
+			if !user.HasManager(managerID) {
+				// manager was already changed outside of this resource
+				return nil
+			}
+			return usersAPI.RemoveManager(userID)
+		},
+	})
+}
@@ -0,0 +1,152 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func userWithManager(managerID string) ScimUser {
+	return ScimUser{
+		ID:       "abc",
+		UserName: "jane@example.com",
+		Enterprise: &enterpriseUser{
+			Manager: &ValueListItem{
+				Value: managerID,
+			},
+		},
+	}
+}
+
+func TestResourceUserManagerCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: patchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []patchOperation{
+						{
+							Op:    "add",
+							Path:  "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager",
+							Value: map[string]interface{}{"value": "bcd"},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/preview/scim/v2/Users/abc",
+				ReuseRequest: true,
+				Response:     userWithManager("bcd"),
+			},
+		},
+		Resource: ResourceUserManager(),
+		State: map[string]interface{}{
+			"user_id":    "abc",
+			"manager_id": "bcd",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|bcd", d.Id())
+}
+
+func TestResourceUserManagerRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: userWithManager("bcd"),
+			},
+		},
+		Resource: ResourceUserManager(),
+		Read:     true,
+		ID:       "abc|bcd",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|bcd", d.Id(), "Id should not be empty")
+}
+
+func TestResourceUserManagerRead_ManagerRemoved(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					ID:       "abc",
+					UserName: "jane@example.com",
+				},
+			},
+		},
+		Resource: ResourceUserManager(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc|bcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourceUserManagerRead_OtherManager(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: userWithManager("cde"),
+			},
+		},
+		Resource: ResourceUserManager(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc|bcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourceUserManagerDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: userWithManager("bcd"),
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: patchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []patchOperation{
+						{
+							Op:   "remove",
+							Path: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceUserManager(),
+		Delete:   true,
+		ID:       "abc|bcd",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|bcd", d.Id())
+}
+
+func TestResourceUserManagerDelete_ManagerAlreadyChanged(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: userWithManager("cde"),
+			},
+		},
+		Resource: ResourceUserManager(),
+		Delete:   true,
+		ID:       "abc|bcd",
+	}.ApplyNoError(t)
+}
@@ -260,6 +260,54 @@ func TestResourceUserUpdate(t *testing.T) {
 	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
 }
 
+func TestResourceUserUpdate_KeepsManager(t *testing.T) {
+	manager := &enterpriseUser{
+		Manager: &ValueListItem{
+			Value: "bcd",
+		},
+	}
+	newUser := ScimUser{
+		Schemas:      []URN{UserSchema, EnterpriseUserSchema},
+		DisplayName:  "Changed Name",
+		UserName:     "me@example.com",
+		Active:       true,
+		Entitlements: []entitlementsListItem{},
+		Enterprise:   manager,
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					DisplayName: "Example user",
+					Active:      true,
+					UserName:    "me@example.com",
+					ID:          "abc",
+					Enterprise:  manager,
+				},
+			},
+			{
+				Method:          "PUT",
+				Resource:        "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: newUser,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: newUser,
+			},
+		},
+		Resource: ResourceUser(),
+		Update:   true,
+		ID:       "abc",
+		HCL: `
+		user_name    = "me@example.com"
+		display_name = "Changed Name"
+		`,
+	}.ApplyNoError(t)
+}
+
 func TestResourceUserUpdate_Error(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -368,3 +416,131 @@ func TestResourceUserDelete_Error(t *testing.T) {
 	}.Apply(t)
 	require.Error(t, err, err)
 }
+
+func TestResourceUserCreate_AllowExisting(t *testing.T) {
+	existing := ScimUser{
+		ID:          "abc",
+		UserName:    "me@example.com",
+		DisplayName: "Old name",
+		Groups: []GroupsListItem{
+			{
+				Display: "ds",
+				Value:   "9877",
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?filter=userName%20eq%20%27me@example.com%27",
+				Response: UserList{
+					Resources: []ScimUser{existing},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: existing,
+			},
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: ScimUser{
+					DisplayName:  "Example user",
+					Active:       true,
+					UserName:     "me@example.com",
+					Schemas:      []URN{UserSchema},
+					Entitlements: []entitlementsListItem{},
+					Groups:       existing.Groups,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					ID:          "abc",
+					UserName:    "me@example.com",
+					DisplayName: "Example user",
+					Active:      true,
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Create:   true,
+		HCL: `
+		user_name      = "me@example.com"
+		display_name   = "Example user"
+		allow_existing = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Example user", d.Get("display_name"))
+}
+
+func TestResourceUserCreate_AllowExistingNotFound(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?filter=userName%20eq%20%27me@example.com%27",
+				Response: UserList{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Users",
+				Response: ScimUser{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					ID:       "abc",
+					UserName: "me@example.com",
+					Active:   true,
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Create:   true,
+		HCL: `
+		user_name      = "me@example.com"
+		allow_existing = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceUserDelete_Deactivate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: patchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []patchOperation{
+						{
+							Op:    "replace",
+							Path:  "active",
+							Value: []interface{}{map[string]interface{}{"value": "false"}},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		State: map[string]interface{}{
+			"user_name":            "me@example.com",
+			"deactivate_on_delete": true,
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
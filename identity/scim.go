@@ -1,5 +1,10 @@
 package identity
 
+import (
+	"fmt"
+	"strings"
+)
+
 // URN is a custom type for the SCIM spec for the schema
 type URN string
 
@@ -10,6 +15,7 @@ const (
 	WorkspaceUserSchema    URN = "urn:ietf:params:scim:schemas:extension:workspace:2.0:User"
 	PatchOp                URN = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
 	GroupSchema            URN = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	EnterpriseUserSchema   URN = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
 )
 
 // MembersValue is a list of value items for the members path
@@ -107,6 +113,16 @@ func (g ScimGroup) HasRole(role string) bool {
 	return false
 }
 
+// HasEntitlement returns true if group has an entitlement
+func (g ScimGroup) HasEntitlement(entitlement Entitlement) bool {
+	for _, groupEntitlement := range g.Entitlements {
+		if groupEntitlement.Value == entitlement {
+			return true
+		}
+	}
+	return false
+}
+
 // GroupList contains a list of groups fetched from a list api call from SCIM api
 type GroupList struct {
 	TotalResults int32       `json:"totalResults,omitempty"`
@@ -130,6 +146,7 @@ const (
 	AllowClusterCreateEntitlement      Entitlement = "allow-cluster-create"
 	AllowInstancePoolCreateEntitlement Entitlement = "allow-instance-pool-create"
 	AllowSQLAnalyticsAccessEntitlement Entitlement = "sql-analytics-access"
+	DatabricksSQLAccessEntitlement     Entitlement = "databricks-sql-access"
 )
 
 type GroupsListItem struct {
@@ -168,6 +185,19 @@ type ScimUser struct {
 	Name          map[string]string      `json:"name,omitempty"`
 	Roles         []roleListItem         `json:"roles,omitempty"`
 	Entitlements  []entitlementsListItem `json:"entitlements,omitempty"`
+	Enterprise    *enterpriseUser        `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+}
+
+// enterpriseUser is the SCIM enterprise extension of the user, that is used
+// to mirror organizational hierarchy
+type enterpriseUser struct {
+	Manager *ValueListItem `json:"manager,omitempty"`
+}
+
+// HasManager returns true if user reports to the given manager
+func (u ScimUser) HasManager(managerID string) bool {
+	return u.Enterprise != nil && u.Enterprise.Manager != nil &&
+		u.Enterprise.Manager.Value == managerID
 }
 
 // HasRole returns true if group has a role
@@ -180,6 +210,16 @@ func (u ScimUser) HasRole(role string) bool {
 	return false
 }
 
+// isAdmin returns true if user is a member of workspace admins group
+func (u ScimUser) isAdmin() bool {
+	for _, g := range u.Groups {
+		if g.Display == "admins" {
+			return true
+		}
+	}
+	return false
+}
+
 // UserList contains a list of Users fetched from a list api call from SCIM api
 type UserList struct {
 	TotalResults int32      `json:"totalResults,omitempty"`
@@ -200,6 +240,20 @@ type patchRequest struct {
 	Operations []patchOperation `json:"Operations,omitempty"`
 }
 
+// scimEqFilter returns SCIM filter on attribute equal to the value. Backslashes and
+// single quotes are escaped, so that the value cannot alter the filter.
+func scimEqFilter(attribute, value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return fmt.Sprintf(`%s eq '%s'`, attribute, escaped)
+}
+
+// scimValueFilter returns SCIM path with a filter on value of multi-valued attribute.
+// Backslashes and double quotes are escaped, so that the value cannot alter the filter.
+func scimValueFilter(attribute, value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf(`%s[value eq "%s"]`, attribute, escaped)
+}
+
 func scimPatchRequest(op, path, value string) patchRequest {
 	o := patchOperation{
 		Op:   op,
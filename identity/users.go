@@ -60,7 +60,7 @@ func (u UserEntity) toRequest() ScimUser {
 
 // Create ..
 func (a UsersAPI) Create(ru UserEntity) (user ScimUser, err error) {
-	err = a.client.Scim(a.context, http.MethodPost, "/preview/scim/v2/Users", ru.toRequest(), &user)
+	err = a.client.Scim(a.context, http.MethodPost, "/Users", ru.toRequest(), &user)
 	return user, err
 }
 
@@ -71,7 +71,7 @@ func (a UsersAPI) Filter(filter string) (u []ScimUser, err error) {
 	if filter != "" {
 		req["filter"] = filter
 	}
-	err = a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Users", req, &users)
+	err = a.client.Scim(a.context, http.MethodGet, "/Users", req, &users)
 	if err != nil {
 		return
 	}
@@ -94,19 +94,21 @@ func (a UsersAPI) Read(userID string) (ru UserEntity, err error) {
 			ru.AllowClusterCreate = true
 		case AllowInstancePoolCreateEntitlement:
 			ru.AllowInstancePoolCreate = true
+		case AllowSQLAnalyticsAccessEntitlement:
+			ru.AllowSQLAnalyticsAccess = true
 		}
 	}
 	return
 }
 
 func (a UsersAPI) read(userID string) (ScimUser, error) {
-	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v", userID)
+	userPath := fmt.Sprintf("/Users/%v", userID)
 	return a.readByPath(userPath)
 }
 
 // Me gets user information about caller
 func (a UsersAPI) Me() (ScimUser, error) {
-	return a.readByPath("/preview/scim/v2/Me")
+	return a.readByPath("/Me")
 }
 
 func (a UsersAPI) readByPath(userPath string) (user ScimUser, err error) {
@@ -123,18 +125,76 @@ func (a UsersAPI) Update(userID string, ru UserEntity) error {
 	updateRequest := ru.toRequest()
 	updateRequest.Groups = user.Groups
 	updateRequest.Roles = user.Roles
+	if user.Enterprise != nil {
+		// manager is kept, as it's set by databricks_user_manager
+		updateRequest.Schemas = append(updateRequest.Schemas, EnterpriseUserSchema)
+		updateRequest.Enterprise = user.Enterprise
+	}
 	return a.client.Scim(a.context, http.MethodPut,
-		fmt.Sprintf("/preview/scim/v2/Users/%v", userID),
+		fmt.Sprintf("/Users/%v", userID),
 		updateRequest, nil)
 }
 
 // Patch updates resource-friendly entity
 func (a UsersAPI) Patch(userID string, r patchRequest) error {
-	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/preview/scim/v2/Users/%v", userID), r, nil)
+	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/Users/%v", userID), r, nil)
+}
+
+// ReadByUserName returns user with given user name, if it exists
+func (a UsersAPI) ReadByUserName(userName string) (user ScimUser, found bool, err error) {
+	users, err := a.Filter(scimEqFilter("userName", userName))
+	if err != nil || len(users) == 0 {
+		return
+	}
+	return users[0], true, nil
+}
+
+// Deactivate disables the user, retaining its assets and group memberships
+func (a UsersAPI) Deactivate(userID string) error {
+	return a.Patch(userID, patchRequest{
+		Schemas: []URN{PatchOp},
+		Operations: []patchOperation{
+			{
+				Op:    "replace",
+				Path:  "active",
+				Value: []ValueListItem{{Value: "false"}},
+			},
+		},
+	})
+}
+
+// SetManager sets manager of the user through SCIM enterprise extension
+func (a UsersAPI) SetManager(userID, managerID string) error {
+	return a.Patch(userID, patchRequest{
+		Schemas: []URN{PatchOp},
+		Operations: []patchOperation{
+			{
+				Op:    "add",
+				Path:  managerPath,
+				Value: ValueListItem{Value: managerID},
+			},
+		},
+	})
 }
 
+// RemoveManager removes manager of the user
+func (a UsersAPI) RemoveManager(userID string) error {
+	return a.Patch(userID, patchRequest{
+		Schemas: []URN{PatchOp},
+		Operations: []patchOperation{
+			{
+				Op:   "remove",
+				Path: managerPath,
+			},
+		},
+	})
+}
+
+// managerPath is the SCIM path of manager attribute in enterprise user extension
+const managerPath = string(EnterpriseUserSchema) + ":manager"
+
 // Delete will delete the user given the user id
 func (a UsersAPI) Delete(userID string) error {
-	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v", userID)
+	userPath := fmt.Sprintf("/Users/%v", userID)
 	return a.client.Scim(a.context, http.MethodDelete, userPath, nil, nil)
 }
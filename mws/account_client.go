@@ -0,0 +1,27 @@
+package mws
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accountResource fails the plan, when account-level resource is configured with
+// workspace host, instead of failing with less clear error during apply
+func accountResource(name string, r *schema.Resource) *schema.Resource {
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if c, ok := m.(*common.DatabricksClient); ok {
+			if err := c.ValidateAccountClient(name); err != nil {
+				return err
+			}
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(ctx, d, m)
+	}
+	return r
+}
@@ -62,7 +62,7 @@ func (a CredentialsAPI) List(mwsAcctID string) ([]Credentials, error) {
 // ResourceCredentials ...
 func ResourceCredentials() *schema.Resource {
 	p := common.NewPairSeparatedID("account_id", "credentials_id", "/")
-	return common.Resource{
+	return accountResource("databricks_mws_credentials", common.Resource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			accountID := d.Get("account_id").(string)
 			roleArn := d.Get("role_arn").(string)
@@ -126,5 +126,5 @@ func ResourceCredentials() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}.ToResource()
+	}.ToResource())
 }
@@ -211,3 +211,19 @@ func TestResourceCredentialsDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/cid", d.Id())
 }
+
+func TestResourceCredentialsCreate_WorkspaceClient(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceCredentials(),
+		Azure:    true,
+		State: map[string]interface{}{
+			"account_id":       "abc",
+			"credentials_name": "Cross-account ARN",
+			"role_arn":         "arn:aws:iam::098765:role/cross-account",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "databricks_mws_credentials requires host "+
+		"to be https://accounts.cloud.databricks.com")
+}
@@ -4,9 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// List of use cases for customer managed keys
+const (
+	CustomerManagedKeyUseCaseManagedServices = "MANAGED_SERVICES"
+	CustomerManagedKeyUseCaseStorage         = "STORAGE"
 )
 
 // AwsKeyInfo has information about the KMS key for BYOK
@@ -22,6 +30,20 @@ type CustomerManagedKey struct {
 	AwsKeyInfo           *AwsKeyInfo `json:"aws_key_info"`
 	AccountID            string      `json:"account_id"`
 	CreationTime         int64       `json:"creation_time,omitempty" tf:"computed"`
+	UseCases             []string    `json:"use_cases,omitempty"`
+}
+
+// validateKeyArn checks, that key_arn refers to AWS KMS key
+func validateKeyArn(i interface{}, k string) (_ []string, errs []error) {
+	keyArn, err := arn.Parse(i.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a valid ARN: %v", k, err))
+		return
+	}
+	if keyArn.Service != "kms" {
+		errs = append(errs, fmt.Errorf("%s must refer to KMS key, not %s", k, keyArn.Service))
+	}
+	return
 }
 
 // NewCustomerManagedKeysAPI creates CustomerManagedKeysAPI instance from provider meta
@@ -68,10 +90,18 @@ func ResourceCustomerManagedKey() *schema.Resource {
 		func(s map[string]*schema.Schema) map[string]*schema.Schema {
 			s["aws_key_info"].ForceNew = true
 			s["account_id"].ForceNew = true
+			s["use_cases"].ForceNew = true
+			s["use_cases"].Elem.(*schema.Schema).ValidateFunc = validation.StringInSlice([]string{
+				CustomerManagedKeyUseCaseManagedServices,
+				CustomerManagedKeyUseCaseStorage,
+			}, false)
+			if keyArn, err := common.SchemaPath(s, "aws_key_info", "key_arn"); err == nil {
+				keyArn.ValidateFunc = validateKeyArn
+			}
 			return s
 		})
 	p := common.NewPairSeparatedID("account_id", "customer_managed_key_id", "/")
-	return common.Resource{
+	return accountResource("databricks_mws_customer_managed_keys", common.Resource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var cmk CustomerManagedKey
 			if err := common.DataToStructPointer(d, s, &cmk); err != nil {
@@ -104,5 +134,5 @@ func ResourceCustomerManagedKey() *schema.Resource {
 			return NewCustomerManagedKeysAPI(ctx, c).Delete(accountID, cmkID)
 		},
 		Schema: s,
-	}.ToResource()
+	}.ToResource())
 }
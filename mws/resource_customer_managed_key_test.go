@@ -53,7 +53,7 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 				ExpectedRequest: CustomerManagedKey{
 					AccountID: "abc",
 					AwsKeyInfo: &AwsKeyInfo{
-						KeyArn:   "key-arn",
+						KeyArn:   "arn:aws:kms:us-east-1:123456789012:key/key-id",
 						KeyAlias: "key-alias",
 					},
 				},
@@ -67,7 +67,7 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 				Response: CustomerManagedKey{
 					CustomerManagedKeyID: "cmkid",
 					AwsKeyInfo: &AwsKeyInfo{
-						KeyArn:    "key-arn",
+						KeyArn:    "arn:aws:kms:us-east-1:123456789012:key/key-id",
 						KeyAlias:  "key-alias",
 						KeyRegion: "us-east-1",
 					},
@@ -81,7 +81,7 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 			account_id = "abc"
 
 			aws_key_info {
-				key_arn   = "key-arn"
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
 				key_alias = "key-alias"
 			}
 		`,
@@ -89,10 +89,94 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/cmkid", d.Id())
-	assert.Equal(t, "key-arn", d.Get("aws_key_info.0.key_arn"))
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/key-id", d.Get("aws_key_info.0.key_arn"))
 	assert.Equal(t, "key-alias", d.Get("aws_key_info.0.key_alias"))
 }
 
+func TestResourceCustomerManagedKeyCreate_UseCases(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys",
+				ExpectedRequest: CustomerManagedKey{
+					AccountID: "abc",
+					AwsKeyInfo: &AwsKeyInfo{
+						KeyArn:   "arn:aws:kms:us-east-1:123456789012:key/key-id",
+						KeyAlias: "key-alias",
+					},
+					UseCases: []string{"MANAGED_SERVICES", "STORAGE"},
+				},
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "cmkid",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys/cmkid",
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "cmkid",
+					AwsKeyInfo: &AwsKeyInfo{
+						KeyArn:    "arn:aws:kms:us-east-1:123456789012:key/key-id",
+						KeyAlias:  "key-alias",
+						KeyRegion: "us-east-1",
+					},
+					AccountID:    "abc",
+					CreationTime: 123,
+					UseCases:     []string{"MANAGED_SERVICES", "STORAGE"},
+				},
+			},
+		},
+		Resource: ResourceCustomerManagedKey(),
+		HCL: `
+			account_id = "abc"
+			use_cases = ["MANAGED_SERVICES", "STORAGE"]
+
+			aws_key_info {
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
+				key_alias = "key-alias"
+			}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/cmkid", d.Id())
+	assert.Equal(t, "STORAGE", d.Get("use_cases.1"))
+}
+
+func TestResourceCustomerManagedKeyCreate_InvalidUseCase(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceCustomerManagedKey(),
+		HCL: `
+			account_id = "abc"
+			use_cases = ["NOTEBOOKS"]
+
+			aws_key_info {
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
+				key_alias = "key-alias"
+			}
+		`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [use_cases.#] expected "+
+		"use_cases.0 to be one of [MANAGED_SERVICES STORAGE], got NOTEBOOKS")
+}
+
+func TestResourceCustomerManagedKeyCreate_InvalidKeyArn(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceCustomerManagedKey(),
+		HCL: `
+			account_id = "abc"
+
+			aws_key_info {
+				key_arn   = "arn:aws:iam::123456789012:role/key-id"
+				key_alias = "key-alias"
+			}
+		`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [aws_key_info.#.key_arn] "+
+		"aws_key_info.0.key_arn must refer to KMS key, not iam")
+}
+
 func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -102,7 +186,7 @@ func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 				ExpectedRequest: CustomerManagedKey{
 					AccountID: "abc",
 					AwsKeyInfo: &AwsKeyInfo{
-						KeyArn:   "key-arn",
+						KeyArn:   "arn:aws:kms:us-east-1:123456789012:key/key-id",
 						KeyAlias: "key-alias",
 					},
 				},
@@ -118,7 +202,7 @@ func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 				Response: CustomerManagedKey{
 					CustomerManagedKeyID: "cmkid",
 					AwsKeyInfo: &AwsKeyInfo{
-						KeyArn:    "key-arn",
+						KeyArn:    "arn:aws:kms:us-east-1:123456789012:key/key-id",
 						KeyAlias:  "key-alias",
 						KeyRegion: "us-east-1",
 					},
@@ -132,7 +216,7 @@ func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 			account_id = "abc"
 
 			aws_key_info {
-				key_arn   = "key-arn"
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
 				key_alias = "key-alias"
 			}
 		`,
@@ -150,7 +234,7 @@ func TestResourceCustomerManagedKeyRead(t *testing.T) {
 				Response: CustomerManagedKey{
 					CustomerManagedKeyID: "cmkid",
 					AwsKeyInfo: &AwsKeyInfo{
-						KeyArn:    "key-arn",
+						KeyArn:    "arn:aws:kms:us-east-1:123456789012:key/key-id",
 						KeyAlias:  "key-alias",
 						KeyRegion: "us-east-1",
 					},
@@ -164,7 +248,7 @@ func TestResourceCustomerManagedKeyRead(t *testing.T) {
 			account_id = "abc"
 
 			aws_key_info {
-				key_arn   = "key-arn"
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
 				key_alias = "key-alias"
 			}
 		`,
@@ -173,7 +257,7 @@ func TestResourceCustomerManagedKeyRead(t *testing.T) {
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/cmkid", d.Id())
-	assert.Equal(t, "key-arn", d.Get("aws_key_info.0.key_arn"))
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/key-id", d.Get("aws_key_info.0.key_arn"))
 	assert.Equal(t, "key-alias", d.Get("aws_key_info.0.key_alias"))
 	assert.Equal(t, "us-east-1", d.Get("aws_key_info.0.key_region"))
 	assert.Equal(t, "abc", d.Get("account_id"))
@@ -198,7 +282,7 @@ func TestResourceCustomerManagedKeyRead_NotFound(t *testing.T) {
 			account_id = "abc"
 
 			aws_key_info {
-				key_arn   = "key-arn"
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
 				key_alias = "key-alias"
 			}
 		`,
@@ -222,7 +306,7 @@ func TestResourceCustomerManagedKeyDelete(t *testing.T) {
 			account_id = "abc"
 
 			aws_key_info {
-				key_arn   = "key-arn"
+				key_arn   = "arn:aws:kms:us-east-1:123456789012:key/key-id"
 				key_alias = "key-alias"
 			}
 		`,
@@ -79,7 +79,7 @@ func ResourceLogDelivery() *schema.Resource {
 			}
 			return s
 		})
-	return common.Resource{
+	return accountResource("databricks_mws_log_delivery", common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ldc LogDeliveryConfiguration
@@ -119,5 +119,5 @@ func ResourceLogDelivery() *schema.Resource {
 			}
 			return NewLogDeliveryAPI(ctx, c).Disable(accountID, configID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
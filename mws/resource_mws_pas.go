@@ -60,7 +60,7 @@ func ResourcePrivateAccessSettings() *schema.Resource {
 		return s
 	})
 	p := common.NewPairSeparatedID("account_id", "private_access_settings_id", "/")
-	return common.Resource{
+	return accountResource("databricks_mws_private_access_settings", common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var pas PrivateAccessSettings
@@ -92,5 +92,5 @@ func ResourcePrivateAccessSettings() *schema.Resource {
 			}
 			return NewPrivateAccessSettingsAPI(ctx, c).Delete(accountID, pasID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
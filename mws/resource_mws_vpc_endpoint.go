@@ -81,7 +81,7 @@ func ResourceVPCEndpoint() *schema.Resource {
 		return s
 	})
 	p := common.NewPairSeparatedID("account_id", "vpc_endpoint_id", "/")
-	return common.Resource{
+	return accountResource("databricks_mws_vpc_endpoint", common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var vpcEndpoint VPCEndpoint
@@ -113,5 +113,5 @@ func ResourceVPCEndpoint() *schema.Resource {
 			}
 			return NewVPCEndpointAPI(ctx, c).Delete(accountID, vpcEndpointID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
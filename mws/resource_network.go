@@ -79,7 +79,7 @@ func ResourceNetwork() *schema.Resource {
 		return s
 	})
 	p := common.NewPairSeparatedID("account_id", "network_id", "/")
-	return common.Resource{
+	return accountResource("databricks_mws_networks", common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var network Network
@@ -111,5 +111,5 @@ func ResourceNetwork() *schema.Resource {
 			}
 			return NewNetworksAPI(ctx, c).Delete(accountID, networkID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
@@ -114,6 +114,9 @@ func (a WorkspacesAPI) Patch(ws Workspace, timeout time.Duration) error {
 		IsNoPublicIPEnabled:    ws.IsNoPublicIPEnabled,
 		NetworkID:              ws.NetworkID,
 		CustomerManagedKeyID:   ws.CustomerManagedKeyID,
+
+		ManagedServicesCustomerManagedKeyID: ws.ManagedServicesCustomerManagedKeyID,
+		StorageCustomerManagedKeyID:         ws.StorageCustomerManagedKeyID,
 	})
 	if err != nil {
 		return err
@@ -161,6 +164,31 @@ func (a WorkspacesAPI) List(mwsAcctID string) ([]Workspace, error) {
 	return mwsWorkspacesList, err
 }
 
+// validateCustomerManagedKeys checks, that key configurations associated
+// with the workspace exist in the account
+func validateCustomerManagedKeys(ctx context.Context, c *common.DatabricksClient, ws Workspace) error {
+	cmkAPI := NewCustomerManagedKeysAPI(ctx, c)
+	for _, key := range []struct {
+		attr, keyID string
+	}{
+		{"managed_services_customer_managed_key_id", ws.ManagedServicesCustomerManagedKeyID},
+		{"storage_customer_managed_key_id", ws.StorageCustomerManagedKeyID},
+	} {
+		attr, keyID := key.attr, key.keyID
+		if keyID == "" {
+			continue
+		}
+		_, err := cmkAPI.Read(ws.AccountID, keyID)
+		if e, ok := err.(common.APIError); ok && e.IsMissing() {
+			return fmt.Errorf("%s refers to missing customer managed key %s", attr, keyID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ResourceWorkspace manages E2 workspaces
 func ResourceWorkspace() *schema.Resource {
 	s := common.StructToSchema(Workspace{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
@@ -184,7 +212,7 @@ func ResourceWorkspace() *schema.Resource {
 		func(_ map[string]*schema.Schema) map[string]*schema.Schema {
 			return s
 		})
-	return common.Resource{
+	return accountResource("databricks_mws_workspaces", common.Resource{
 		Schema:        s,
 		SchemaVersion: 2,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -193,6 +221,9 @@ func ResourceWorkspace() *schema.Resource {
 			if err := common.DataToStructPointer(d, s, &workspace); err != nil {
 				return err
 			}
+			if err := validateCustomerManagedKeys(ctx, c, workspace); err != nil {
+				return err
+			}
 			if err := workspacesAPI.Create(&workspace, d.Timeout(schema.TimeoutCreate)); err != nil {
 				return err
 			}
@@ -222,6 +253,9 @@ func ResourceWorkspace() *schema.Resource {
 			if err := common.DataToStructPointer(d, s, &workspace); err != nil {
 				return err
 			}
+			if err := validateCustomerManagedKeys(ctx, c, workspace); err != nil {
+				return err
+			}
 			return workspacesAPI.Patch(workspace, d.Timeout(schema.TimeoutUpdate))
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -236,5 +270,5 @@ func ResourceWorkspace() *schema.Resource {
 			Read:   schema.DefaultTimeout(DefaultProvisionTimeout),
 			Update: schema.DefaultTimeout(DefaultProvisionTimeout),
 		},
-	}.ToResource()
+	}.ToResource())
 }
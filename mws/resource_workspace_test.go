@@ -85,6 +85,113 @@ func TestResourceWorkspaceCreate(t *testing.T) {
 	assert.Equal(t, "abc/1234", d.Id())
 }
 
+func TestResourceWorkspaceCreate_CustomerManagedKeys(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys/jkl",
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "jkl",
+					AccountID:            "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys/mno",
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "mno",
+					AccountID:            "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/workspaces",
+				ExpectedRequest: Workspace{
+					AccountID:              "abc",
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+
+					ManagedServicesCustomerManagedKeyID: "jkl",
+					StorageCustomerManagedKeyID:         "mno",
+				},
+				Response: Workspace{
+					WorkspaceID:    1234,
+					AccountID:      "abc",
+					DeploymentName: "900150983cd24fb0",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:            1234,
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+
+					ManagedServicesCustomerManagedKeyID: "jkl",
+					StorageCustomerManagedKeyID:         "mno",
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		State: map[string]interface{}{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"storage_configuration_id": "ghi",
+
+			"managed_services_customer_managed_key_id": "jkl",
+			"storage_customer_managed_key_id":          "mno",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id())
+	assert.Equal(t, "jkl", d.Get("managed_services_customer_managed_key_id"))
+	assert.Equal(t, "mno", d.Get("storage_customer_managed_key_id"))
+}
+
+func TestResourceWorkspaceCreate_MissingCustomerManagedKey(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys/jkl",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Key configuration jkl does not exist",
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		State: map[string]interface{}{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"storage_configuration_id": "ghi",
+
+			"managed_services_customer_managed_key_id": "jkl",
+		},
+		Create: true,
+	}.ExpectError(t, "managed_services_customer_managed_key_id refers to "+
+		"missing customer managed key jkl")
+}
+
 func TestResourceWorkspaceCreate_Error(t *testing.T) {
 	t.Skipf("Making this test skip until we can configure sleep timings for test purposes")
 	d, err := qa.ResourceFixture{
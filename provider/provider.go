@@ -30,10 +30,13 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_dbfs_file":               storage.DataSourceDBFSFile(),
 			"databricks_dbfs_file_paths":         storage.DataSourceDBFSFilePaths(),
 			"databricks_group":                   identity.DataSourceGroup(),
+			"databricks_instance_pool":           compute.DataSourceInstancePool(),
+			"databricks_mounts":                  storage.DataSourceMounts(),
 			"databricks_node_type":               compute.DataSourceNodeType(),
 			"databricks_notebook":                workspace.DataSourceNotebook(),
 			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
 			"databricks_spark_version":           compute.DataSourceSparkVersion(),
+			"databricks_workspace_conf":          workspace.DataSourceWorkspaceConf(),
 			"databricks_zones":                   compute.DataSourceClusterZones(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -49,8 +52,12 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_job":            compute.ResourceJob(),
 
 			"databricks_group":                  identity.ResourceGroup(),
+			"databricks_group_entitlement":      identity.ResourceGroupEntitlement(),
 			"databricks_group_instance_profile": identity.ResourceGroupInstanceProfile(),
+			"databricks_group_role":             identity.ResourceGroupRole(),
+			"databricks_group_sync":             identity.ResourceGroupSync(),
 			"databricks_user_instance_profile":  identity.ResourceUserInstanceProfile(),
+			"databricks_user_manager":           identity.ResourceUserManager(),
 			"databricks_instance_profile":       identity.ResourceInstanceProfile(),
 			"databricks_group_member":           identity.ResourceGroupMember(),
 			"databricks_token":                  identity.ResourceToken(),
@@ -71,9 +78,11 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_azure_adls_gen2_mount": storage.ResourceAzureAdlsGen2Mount(),
 			"databricks_azure_blob_mount":      storage.ResourceAzureBlobMount(),
 			"databricks_dbfs_file":             storage.ResourceDBFSFile(),
+			"databricks_gcs_mount":             storage.ResourceGoogleCloudStorageMount(),
 
 			"databricks_sql_endpoint": sqlanalytics.ResourceSQLEndpoint(),
 
+			"databricks_directory":          workspace.ResourceDirectory(),
 			"databricks_global_init_script": workspace.ResourceGlobalInitScript(),
 			"databricks_notebook":           workspace.ResourceNotebook(),
 			"databricks_workspace_conf":     workspace.ResourceWorkspaceConf(),
@@ -197,6 +206,12 @@ func DatabricksProvider() *schema.Provider {
 				Description: "Currently secret scopes are not accessible via AAD tokens so we will need to create a PAT token",
 				Default:     "3600",
 			},
+			"azure_use_msi": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Use Azure Managed Identity to authenticate, if it's available on the host",
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_MSI", false),
+			},
 			"azure_use_pat_for_cli": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -226,12 +241,42 @@ func DatabricksProvider() *schema.Provider {
 				Description: "Debug HTTP headers of requests made by the provider. Default is false. Visible only when TF_LOG=DEBUG is set",
 				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_DEBUG_HEADERS", false),
 			},
+			"http_timeout_seconds": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "Number of seconds, after which an HTTP request to Databricks REST API is failed.",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_HTTP_TIMEOUT_SECONDS", common.DefaultHTTPTimeoutSeconds),
+			},
 			"rate_limit": {
 				Optional:    true,
 				Type:        schema.TypeInt,
 				Description: "Maximum number of requests per second made to Databricks REST API by Terraform.",
 				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_RATE_LIMIT", common.DefaultRateLimitPerSecond),
 			},
+			"default_cluster_tags": {
+				Optional:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom tags to add to clusters, that are created by the provider for mounting storage.",
+			},
+			"cluster_create_max_attempts": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "Maximum number of attempts to launch a cluster, that failed to start because of transient cloud provider failure.",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_CLUSTER_CREATE_MAX_ATTEMPTS", common.DefaultClusterCreateMaxAttempts),
+			},
+			"cloud": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Cloud of the workspace: aws, azure or gcp. Detected from the host by default.",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_CLOUD", nil),
+			},
+			"account_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Account ID for account-level resources. Host defaults to the accounts host, if it is not set.",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_ACCOUNT_ID", nil),
+			},
 		},
 	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -319,6 +364,10 @@ func configureDatabricksClient(ctx context.Context, d *schema.ResourceData) (int
 		authsUsed["azure"] = true
 		pc.AzureAuth.TenantID = v.(string)
 	}
+	if v, ok := d.GetOk("azure_use_msi"); ok {
+		authsUsed["azure"] = true
+		pc.AzureAuth.UseMSI = v.(bool)
+	}
 	if v, ok := d.GetOk("azure_pat_token_duration_seconds"); ok {
 		pc.AzureAuth.PATTokenDurationSeconds = v.(string)
 	}
@@ -328,12 +377,36 @@ func configureDatabricksClient(ctx context.Context, d *schema.ResourceData) (int
 	if v, ok := d.GetOk("debug_truncate_bytes"); ok {
 		pc.DebugTruncateBytes = v.(int)
 	}
+	if v, ok := d.GetOk("http_timeout_seconds"); ok {
+		pc.HTTPTimeoutSeconds = v.(int)
+	}
 	if v, ok := d.GetOk("rate_limit"); ok {
 		pc.RateLimitPerSecond = v.(int)
 	}
 	if v, ok := d.GetOk("debug_headers"); ok {
 		pc.DebugHeaders = v.(bool)
 	}
+	if v, ok := d.GetOk("default_cluster_tags"); ok {
+		pc.DefaultClusterTags = map[string]string{}
+		for key, value := range v.(map[string]interface{}) {
+			pc.DefaultClusterTags[key] = value.(string)
+		}
+	}
+	if v, ok := d.GetOk("cluster_create_max_attempts"); ok {
+		pc.ClusterCreateMaxAttempts = v.(int)
+	}
+	if v, ok := d.GetOk("cloud"); ok {
+		switch v.(string) {
+		case common.CloudAWS, common.CloudAzure, common.CloudGCP:
+			pc.Cloud = v.(string)
+		default:
+			return nil, diag.Errorf("cloud must be one of %s, %s or %s, but is %s",
+				common.CloudAWS, common.CloudAzure, common.CloudGCP, v)
+		}
+	}
+	if v, ok := d.GetOk("account_id"); ok {
+		pc.AccountID = v.(string)
+	}
 	if v, ok := d.GetOk("azure_use_pat_for_cli"); ok {
 		pc.AzureAuth.UsePATForCLI = v.(bool)
 	}
@@ -344,7 +344,7 @@ func configureProviderAndReturnClient(t *testing.T, tt providerConfigTest) (*com
 		return nil, fmt.Errorf(strings.Join(issues, ", "))
 	}
 	client := p.Meta().(*common.DatabricksClient)
-	err := client.Authenticate()
+	err := client.Authenticate(context.Background())
 	if err != nil {
 		return nil, err
 	}
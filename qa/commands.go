@@ -0,0 +1,68 @@
+package qa
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/internal"
+)
+
+// CommandResponse is scripted result of command execution
+type CommandResponse struct {
+	Result string
+	Err    error
+}
+
+// CommandRecorder captures all executed commands for post-hoc assertions and replies
+// with scripted responses. Responses are returned in the order of execution, so that
+// failure on Nth call is scripted by putting error at N-1 position. Default response
+// is returned once scripted responses are exhausted.
+type CommandRecorder struct {
+	Responses []CommandResponse
+	Default   CommandResponse
+
+	mu       sync.Mutex
+	commands []string
+}
+
+// Mock returns command mock, that is recording all of the executions
+func (cr *CommandRecorder) Mock() common.CommandMock {
+	return func(commandStr string) (string, error) {
+		cr.mu.Lock()
+		defer cr.mu.Unlock()
+		call := len(cr.commands)
+		cr.commands = append(cr.commands, internal.TrimLeadingWhitespace(commandStr))
+		if call < len(cr.Responses) {
+			return cr.Responses[call].Result, cr.Responses[call].Err
+		}
+		return cr.Default.Result, cr.Default.Err
+	}
+}
+
+// Commands returns all executed commands with leading whitespace trimmed
+func (cr *CommandRecorder) Commands() []string {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return append([]string{}, cr.commands...)
+}
+
+// Command returns Nth executed command, starting from zero
+func (cr *CommandRecorder) Command(n int) (string, error) {
+	commands := cr.Commands()
+	if n >= len(commands) {
+		return "", fmt.Errorf("Only %d commands were executed", len(commands))
+	}
+	return commands[n], nil
+}
+
+// Executed returns number of executed commands containing given substring
+func (cr *CommandRecorder) Executed(substr string) (count int) {
+	for _, command := range cr.Commands() {
+		if strings.Contains(command, substr) {
+			count++
+		}
+	}
+	return
+}
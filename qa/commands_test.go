@@ -0,0 +1,65 @@
+package qa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandRecorder(t *testing.T) {
+	cr := &CommandRecorder{
+		Responses: []CommandResponse{
+			{Result: "first"},
+			{Err: errors.New("second fails")},
+		},
+		Default: CommandResponse{Result: "default"},
+	}
+	mock := cr.Mock()
+
+	result, err := mock(`
+		print("a")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", result)
+
+	_, err = mock(`print("b")`)
+	assert.EqualError(t, err, "second fails")
+
+	result, err = mock(`print("a")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", result)
+
+	assert.Equal(t, []string{"print(\"a\")\n", "print(\"b\")\n", "print(\"a\")\n"}, cr.Commands())
+	assert.Equal(t, 2, cr.Executed(`"a"`))
+	assert.Equal(t, 0, cr.Executed("nothing"))
+
+	command, err := cr.Command(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "print(\"b\")\n", command)
+
+	_, err = cr.Command(3)
+	assert.EqualError(t, err, "Only 3 commands were executed")
+}
+
+func TestResourceFixture_CommandMockAndRecorder(t *testing.T) {
+	_, err := ResourceFixture{
+		CommandMock: func(commandStr string) (string, error) {
+			return "", nil
+		},
+		CommandRecorder: &CommandRecorder{},
+		Create:          true,
+	}.Apply(t)
+	assert.EqualError(t, err, "CommandMock and CommandRecorder cannot be used together")
+}
+
+func TestResourceFixture_CommandResultsMockAndRecorder(t *testing.T) {
+	_, err := ResourceFixture{
+		CommandResultsMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{}
+		},
+		CommandRecorder: &CommandRecorder{},
+		Create:          true,
+	}.Apply(t)
+	assert.EqualError(t, err, "CommandResultsMock cannot be used with other command mocks")
+}
@@ -63,8 +63,10 @@ type HTTPFixture struct {
 	Response        interface{}
 	Status          int
 	ExpectedRequest interface{}
-	ReuseRequest    bool
-	MatchAny        bool
+	// ExpectedPartialRequest only checks fields present in it, ignoring the rest of request body
+	ExpectedPartialRequest interface{}
+	ReuseRequest           bool
+	MatchAny               bool
 }
 
 // ResourceFixture helps testing resources and commands
@@ -75,9 +77,16 @@ type ResourceFixture struct {
 	InstanceState map[string]string
 	State         map[string]interface{}
 	// HCL might be useful to test nested blocks
-	HCL         string
+	HCL string
+	// CommandMock returns text output or error of a command
 	CommandMock common.CommandMock
-	Create      bool
+	// CommandResultsMock returns structured results of a command
+	CommandResultsMock common.CommandResultsMock
+	// CommandRecorder captures executed commands and cannot be used with CommandMock
+	CommandRecorder *CommandRecorder
+	Create          bool
+	// Plan only validates the configuration and computes the diff, without calling CRUD
+	Plan        bool
 	Read        bool
 	Update      bool
 	Delete      bool
@@ -96,9 +105,21 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.CommandMock != nil && f.CommandRecorder != nil {
+		return nil, errors.New("CommandMock and CommandRecorder cannot be used together")
+	}
+	if f.CommandResultsMock != nil && (f.CommandMock != nil || f.CommandRecorder != nil) {
+		return nil, errors.New("CommandResultsMock cannot be used with other command mocks")
+	}
 	if f.CommandMock != nil {
 		client.WithCommandMock(f.CommandMock)
 	}
+	if f.CommandResultsMock != nil {
+		client.WithCommandResultsMock(f.CommandResultsMock)
+	}
+	if f.CommandRecorder != nil {
+		client.WithCommandMock(f.CommandRecorder.Mock())
+	}
 	if f.Azure {
 		client.AzureAuth.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
 	}
@@ -128,6 +149,10 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	}
 	resourceConfig := terraform.NewResourceConfigRaw(f.State)
 	switch {
+	case f.Plan:
+		whatever = func(d *schema.ResourceData, m interface{}) error {
+			return nil
+		}
 	case f.Create:
 		// nolint should be a bigger context-aware refactor
 		whatever = func(d *schema.ResourceData, m interface{}) error {
@@ -200,7 +225,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 		return nil, err
 	}
 	err = whatever(resourceData, client)
-	if err != nil {
+	if err != nil || f.Plan {
 		return resourceData, err
 	}
 	if resourceData.Id() == "" && !f.Removed {
@@ -328,13 +353,20 @@ func HttpFixtureClient(t *testing.T, fixtures []HTTPFixture) (client *common.Dat
 				} else {
 					rw.WriteHeader(fixture.Status)
 				}
-				if fixture.ExpectedRequest != nil {
+				if fixture.ExpectedRequest != nil || fixture.ExpectedPartialRequest != nil {
 					buf := new(bytes.Buffer)
 					_, err := buf.ReadFrom(req.Body)
 					assert.NoError(t, err, err)
-					jsonStr, err := json.Marshal(fixture.ExpectedRequest)
-					assert.NoError(t, err, err)
-					assert.JSONEq(t, string(jsonStr), buf.String(), "json strings do not match")
+					if fixture.ExpectedRequest != nil {
+						jsonStr, err := json.Marshal(fixture.ExpectedRequest)
+						assert.NoError(t, err, err)
+						assert.JSONEq(t, string(jsonStr), buf.String(), "json strings do not match")
+					}
+					if fixture.ExpectedPartialRequest != nil {
+						jsonStr, err := json.Marshal(fixture.ExpectedPartialRequest)
+						assert.NoError(t, err, err)
+						AssertJSONContains(t, string(jsonStr), buf.String())
+					}
 				}
 				if fixture.Response != nil {
 					if alreadyJSON, ok := fixture.Response.(string); ok {
@@ -493,6 +525,60 @@ func FirstKeyValue(t *testing.T, str, key string) string {
 	return match[1]
 }
 
+// AssertJSONContains checks that every field of expected JSON document is present in actual
+// JSON document with the same value. Object keys are order-insensitive and extra keys in
+// actual document are ignored, though arrays have to be of the same length.
+func AssertJSONContains(t assert.TestingT, expected, actual string) bool {
+	var e, a interface{}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		return assert.Fail(t, fmt.Sprintf("Expected value is not valid JSON: %s", err))
+	}
+	if err := json.Unmarshal([]byte(actual), &a); err != nil {
+		return assert.Fail(t, fmt.Sprintf("Actual value is not valid JSON: %s", err))
+	}
+	return jsonContains(t, "$", e, a)
+}
+
+func jsonContains(t assert.TestingT, path string, expected, actual interface{}) bool {
+	switch ev := expected.(type) {
+	case map[string]interface{}:
+		av, ok := actual.(map[string]interface{})
+		if !ok {
+			return assert.Fail(t, fmt.Sprintf("%s: expected object, but got %#v", path, actual))
+		}
+		matches := true
+		for k, v := range ev {
+			fieldPath := fmt.Sprintf("%s.%s", path, k)
+			actualField, ok := av[k]
+			if !ok {
+				matches = assert.Fail(t, fmt.Sprintf("%s is missing", fieldPath))
+				continue
+			}
+			if !jsonContains(t, fieldPath, v, actualField) {
+				matches = false
+			}
+		}
+		return matches
+	case []interface{}:
+		av, ok := actual.([]interface{})
+		if !ok {
+			return assert.Fail(t, fmt.Sprintf("%s: expected array, but got %#v", path, actual))
+		}
+		if !assert.Lenf(t, av, len(ev), "%s has different length", path) {
+			return false
+		}
+		matches := true
+		for i := range ev {
+			if !jsonContains(t, fmt.Sprintf("%s[%d]", path, i), ev[i], av[i]) {
+				matches = false
+			}
+		}
+		return matches
+	default:
+		return assert.Equal(t, expected, actual, path)
+	}
+}
+
 // AssertErrorStartsWith ..
 func AssertErrorStartsWith(t *testing.T, err error, message string) bool {
 	return assert.True(t, strings.HasPrefix(err.Error(), message), err.Error())
@@ -227,6 +227,17 @@ func TestResourceFixture_Apply_Fail(t *testing.T) {
 	assert.EqualError(t, err, "Invalid config supplied. [check] Invalid or unknown key")
 }
 
+func TestResourceFixture_Plan(t *testing.T) {
+	d, err := ResourceFixture{
+		Resource: noopResource,
+		Plan:     true,
+		HCL:      `dummy = true`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, true, d.Get("dummy"))
+}
+
 func TestTestCreateTempFile(t *testing.T) {
 	a := TestCreateTempFile(t, "abc")
 	assert.FileExists(t, a)
@@ -273,3 +284,63 @@ func TestDiagsToString(t *testing.T) {
 		},
 	}))
 }
+
+func TestResourceFixture_PartialRequest(t *testing.T) {
+	client, server, err := HttpFixtureClient(t, []HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/a/b/c",
+			ExpectedPartialRequest: map[string]interface{}{
+				"check": true,
+				"nested": map[string]string{
+					"a": "b",
+				},
+			},
+		},
+	})
+	defer server.Close()
+	assert.NoError(t, err)
+
+	err = client.Post(context.Background(), "/a/b/c", map[string]interface{}{
+		"check":   true,
+		"ignored": "value",
+		"nested": map[string]string{
+			"a": "b",
+			"c": "d",
+		},
+	}, nil)
+	assert.NoError(t, err)
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertJSONContains(t *testing.T) {
+	testCases := []struct {
+		expected string
+		actual   string
+		matches  bool
+	}{
+		{`{}`, `{"a": 1}`, true},
+		{`{"a": 1}`, `{"b": 2, "a": 1}`, true},
+		{`{"a": {"b": [1, {"c": 2}]}}`, `{"a": {"b": [1, {"c": 2, "d": 3}], "e": 4}}`, true},
+		{`{"a": 1}`, `{"b": 1}`, false},
+		{`{"a": 1}`, `{"a": 2}`, false},
+		{`{"a": [1]}`, `{"a": [1, 2]}`, false},
+		{`{"a": {"b": 1}}`, `{"a": "b"}`, false},
+		{`{"a": [1]}`, `{"a": {}}`, false},
+		{`{"a": 1}`, `not json`, false},
+		{`not json`, `{}`, false},
+	}
+	for _, tc := range testCases {
+		rt := &recordingT{}
+		assert.Equal(t, tc.matches, AssertJSONContains(rt, tc.expected, tc.actual),
+			"%s in %s", tc.expected, tc.actual)
+		assert.Equal(t, tc.matches, len(rt.errors) == 0, "%v", rt.errors)
+	}
+}
@@ -24,8 +24,8 @@ func (m AzureADLSGen1Mount) Source() string {
 }
 
 // Config ...
-func (m AzureADLSGen1Mount) Config() map[string]string {
-	return map[string]string{
+func (m AzureADLSGen1Mount) Config() MountConfig {
+	return MountConfig{
 		m.PrefixType + ".oauth2.access.token.provider.type": "ClientCredential",
 
 		m.PrefixType + ".oauth2.client.id":   m.ClientID,
@@ -48,9 +48,10 @@ func ResourceAzureAdlsGen1Mount() *schema.Resource {
 		},
 		"mount_name": {
 			// TODO: have it by default as storage_resource_name
-			Type:     schema.TypeString,
-			Required: true,
-			ForceNew: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateMountName,
 		},
 
 		"storage_resource_name": {
@@ -63,7 +64,6 @@ func ResourceAzureAdlsGen1Mount() *schema.Resource {
 			Optional:     true,
 			Default:      "fs.adl",
 			ValidateFunc: validation.StringInSlice([]string{"fs.adl", "dfs.adls"}, false),
-			ForceNew:     true,
 		},
 		"directory": {
 			Type:     schema.TypeString,
@@ -78,23 +78,19 @@ func ResourceAzureAdlsGen1Mount() *schema.Resource {
 			// TODO: take it from AzureAuth if not speficied
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_id": {
 			// TODO: take it from AzureAuth if not speficied
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_secret_scope": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_secret_key": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 	})
 }
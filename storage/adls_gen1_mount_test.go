@@ -52,7 +52,7 @@ func TestResourceAdlsGen1Mount_Create(t *testing.T) {
 				assert.Contains(t, trunc, `"fs.adl.oauth2.credential":dbutils.secrets.get("c", "d")`)
 			}
 			assert.Contains(t, trunc, "/mnt/this_mount")
-			return testS3BucketPath, nil
+			return testMountListing("this_mount", testS3BucketPath), nil
 		},
 		State: map[string]interface{}{
 			"cluster_id":            "this_cluster",
@@ -25,8 +25,8 @@ func (m AzureADLSGen2Mount) Source() string {
 }
 
 // Config returns mount configurations
-func (m AzureADLSGen2Mount) Config() map[string]string {
-	return map[string]string{
+func (m AzureADLSGen2Mount) Config() MountConfig {
+	return MountConfig{
 		"fs.azure.account.auth.type":                          "OAuth",
 		"fs.azure.account.oauth.provider.type":                "org.apache.hadoop.fs.azurebfs.oauth2.ClientCredsTokenProvider",
 		"fs.azure.account.oauth2.client.id":                   m.ClientID,
@@ -50,9 +50,10 @@ func ResourceAzureAdlsGen2Mount() *schema.Resource {
 		},
 		"mount_name": {
 			// TODO: have it by default as storage_resource_name
-			Type:     schema.TypeString,
-			Required: true,
-			ForceNew: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateMountName,
 		},
 		"container_name": {
 			Type:     schema.TypeString,
@@ -74,27 +75,22 @@ func ResourceAzureAdlsGen2Mount() *schema.Resource {
 		"tenant_id": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_id": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_secret_scope": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"client_secret_key": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"initialize_file_system": {
 			Type:     schema.TypeBool,
 			Required: true,
-			ForceNew: true,
 		},
 	})
 }
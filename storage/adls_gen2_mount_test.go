@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/internal"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -54,7 +56,7 @@ func TestResourceAdlsGen2Mount_Create(t *testing.T) {
 				assert.Contains(t, trunc, `"fs.azure.account.oauth2.client.secret":dbutils.secrets.get("c", "d")`)
 			}
 			assert.Contains(t, trunc, "/mnt/this_mount")
-			return testS3BucketPath, nil
+			return testMountListing("this_mount", testS3BucketPath), nil
 		},
 		State: map[string]interface{}{
 			"cluster_id":             "this_cluster",
@@ -73,3 +75,142 @@ func TestResourceAdlsGen2Mount_Create(t *testing.T) {
 	assert.Equal(t, "this_mount", d.Id())
 	assert.Equal(t, testS3BucketPath, d.Get("source"))
 }
+
+func TestResourceAdlsGen2Mount_Create_OldRuntime(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State:        compute.ClusterStateRunning,
+					SparkVersion: "5.1.x-scala2.11",
+				},
+			},
+		},
+		Resource: ResourceAzureAdlsGen2Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			assert.Fail(t, "Old runtime should be rejected before mounting")
+			return "", nil
+		},
+		State: map[string]interface{}{
+			"cluster_id":             "this_cluster",
+			"container_name":         "e",
+			"mount_name":             "this_mount",
+			"storage_account_name":   "test-adls-gen2",
+			"tenant_id":              "a",
+			"client_id":              "b",
+			"client_secret_scope":    "c",
+			"client_secret_key":      "d",
+			"initialize_file_system": true,
+		},
+		Create: true,
+	}.ExpectError(t, "abfss mounts require Databricks Runtime 5.2 or above, "+
+		"but cluster this_cluster runs 5.1.x-scala2.11")
+}
+
+func TestResourceAdlsGen2Mount_Create_InvalidSecretReference(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAzureAdlsGen2Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			assert.Fail(t, "No commands should be executed during plan")
+			return "", nil
+		},
+		State: map[string]interface{}{
+			"cluster_id":             "this_cluster",
+			"container_name":         "e",
+			"mount_name":             "this_mount",
+			"storage_account_name":   "test-adls-gen2",
+			"tenant_id":              "a",
+			"client_id":              "b",
+			"client_secret_scope":    "c/d",
+			"client_secret_key":      "d",
+			"initialize_file_system": true,
+		},
+		Create: true,
+	}.ExpectError(t, "client_secret_scope and client_secret_key do not form "+
+		"a valid secret reference: {secrets/c/d/d}")
+}
+
+var testAdlsGen2MountState = map[string]string{
+	"cluster_id":             "this_cluster",
+	"container_name":         "e",
+	"mount_name":             "this_mount",
+	"source":                 "abfss://e@test-adls-gen2.dfs.core.windows.net",
+	"storage_account_name":   "test-adls-gen2",
+	"tenant_id":              "a",
+	"client_id":              "b",
+	"client_secret_scope":    "c",
+	"client_secret_key":      "d",
+	"initialize_file_system": "true",
+}
+
+func TestResourceAdlsGen2Mount_Update_ConfigOnly(t *testing.T) {
+	updated := false
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceAzureAdlsGen2Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			if strings.HasPrefix(trunc, "def safe_mount") {
+				assert.Contains(t, trunc, `"abfss://e@test-adls-gen2.dfs.core.windows.net", `)
+				assert.Contains(t, trunc, `dbutils.secrets.get("c", "new_key")`)
+				assert.Contains(t, trunc, `}, True)`, "mount has to be updated in place")
+				updated = true
+			}
+			return testMountListing("this_mount", "abfss://e@test-adls-gen2.dfs.core.windows.net"), nil
+		},
+		InstanceState: testAdlsGen2MountState,
+		HCL: `
+		cluster_id = "this_cluster"
+		container_name = "e"
+		mount_name = "this_mount"
+		storage_account_name = "test-adls-gen2"
+		tenant_id = "a"
+		client_id = "b"
+		client_secret_scope = "c"
+		client_secret_key = "new_key"
+		initialize_file_system = true
+		`,
+		ID:     "this_mount",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.True(t, updated)
+	assert.Equal(t, "new_key", d.Get("client_secret_key"))
+}
+
+func TestResourceAdlsGen2Mount_Diff_SourceChange(t *testing.T) {
+	r := ResourceAzureAdlsGen2Mount()
+	config := map[string]interface{}{}
+	for k, v := range testAdlsGen2MountState {
+		if k != "source" {
+			config[k] = v
+		}
+	}
+	config["initialize_file_system"] = true
+	state := &terraform.InstanceState{
+		ID:         "this_mount",
+		Attributes: testAdlsGen2MountState,
+	}
+
+	config["client_secret_key"] = "new_key"
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew(), "secret change updates mount configuration")
+
+	config["container_name"] = "f"
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew(), "container change requires remount")
+}
@@ -3,39 +3,111 @@ package storage
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/databrickslabs/terraform-provider-databricks/identity"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // AWSIamMount describes the object for a aws mount using iam role
 type AWSIamMount struct {
-	S3BucketName string `json:"s3_bucket_name"`
+	S3BucketName   string `json:"s3_bucket_name"`
+	Scheme         string `json:"scheme,omitempty"`
+	EncryptionType string `json:"encryption_type,omitempty"`
+	KmsKey         string `json:"kms_key,omitempty"`
+}
+
+// S3 server-side encryption types, supported by dbutils.fs.mount
+const (
+	S3EncryptionTypeS3  = "sse-s3"
+	S3EncryptionTypeKMS = "sse-kms"
+)
+
+// Encryption returns encryption type of the mount, optionally followed by the KMS key
+func (m AWSIamMount) Encryption() string {
+	if m.EncryptionType == S3EncryptionTypeKMS && m.KmsKey != "" {
+		return m.EncryptionType + ":" + m.KmsKey
+	}
+	return m.EncryptionType
+}
+
+// parseS3Encryption splits encryption type of the live mount, like `sse-kms:<key>`
+func parseS3Encryption(encryption string) (encryptionType, kmsKey string) {
+	parts := strings.SplitN(encryption, ":", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return encryption, ""
 }
 
 // Source ...
 func (m AWSIamMount) Source() string {
-	return fmt.Sprintf("s3a://%s", m.S3BucketName)
+	scheme := m.Scheme
+	if scheme == "" {
+		scheme = defaultS3Scheme
+	}
+	return fmt.Sprintf("%s://%s", scheme, m.S3BucketName)
+}
+
+// defaultS3Scheme is the URI scheme of S3A filesystem, recommended for all new mounts
+const defaultS3Scheme = "s3a"
+
+// s3Schemes are URI schemes of Hadoop filesystems, that could be used to mount S3 buckets
+var s3Schemes = []string{defaultS3Scheme, "s3n", "s3"}
+
+// parseS3Source splits mount source into URI scheme, bucket name and optional path
+// prefix within the bucket. Scheme is empty, if source is not an S3 URI.
+func parseS3Source(source string) (scheme, bucket, prefix string) {
+	for _, s := range s3Schemes {
+		uriPrefix := s + "://"
+		if !strings.HasPrefix(source, uriPrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(source, uriPrefix), "/", 2)
+		if len(parts) == 2 {
+			prefix = strings.Trim(parts[1], "/")
+		}
+		return s, parts[0], prefix
+	}
+	return "", source, ""
 }
 
 // Config ...
-func (m AWSIamMount) Config() map[string]string {
-	return make(map[string]string) // return empty map so nil map does not marshal to null
+func (m AWSIamMount) Config() MountConfig {
+	return MountConfig{}
+}
+
+// MountingCluster is optional specification of the cluster created to perform mounting
+type MountingCluster struct {
+	SparkVersion   string                 `json:"spark_version,omitempty"`
+	NodeTypeID     string                 `json:"node_type_id,omitempty"`
+	InstancePoolID string                 `json:"instance_pool_id,omitempty"`
+	AwsAttributes  *compute.AwsAttributes `json:"aws_attributes,omitempty"`
+}
+
+type awsS3MountingCluster struct {
+	Cluster *MountingCluster `json:"cluster,omitempty"`
 }
 
 // ResourceAWSS3Mount ...
 func ResourceAWSS3Mount() *schema.Resource {
 	tpl := AWSIamMount{}
 	r := &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: addMountLocationFields(addMountVerificationFields(map[string]*schema.Schema{
 			"cluster_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
+				// checked on raw configuration, as computed cluster_id is never
+				// known in the diff before the first apply
+				AtLeastOneOf: []string{"instance_profile", "instance_profiles",
+					"cluster.0.aws_attributes.0.instance_profile_arn"},
 			},
 			"source": {
 				Type:     schema.TypeString,
@@ -43,77 +115,440 @@ func ResourceAWSS3Mount() *schema.Resource {
 			},
 			"mount_name": {
 				// TODO: have it by default as storage_resource_name
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateMountName,
 			},
 			"s3_bucket_name": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"scheme": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultS3Scheme,
+				ValidateFunc: validation.StringInSlice(s3Schemes, false),
+			},
+			"encryption_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					S3EncryptionTypeS3,
+					S3EncryptionTypeKMS,
+				}, false),
+			},
+			"kms_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"instance_profile": {
 				Type:     schema.TypeString,
 				Optional: true,
+			},
+			"instance_profiles": {
+				Type:     schema.TypeList,
+				Optional: true,
 				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"instance_profile", "cluster_id",
+					"cluster.0.aws_attributes.0.instance_profile_arn"},
 			},
-		},
+			"effective_instance_profile": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"cluster_id"},
+				Elem: &schema.Resource{
+					Schema: common.StructToSchema(MountingCluster{},
+						func(s map[string]*schema.Schema) map[string]*schema.Schema {
+							// node type comes from the pool, so API rejects both
+							s["instance_pool_id"].ConflictsWith = []string{"cluster.0.node_type_id"}
+							return s
+						}),
+				},
+			},
+		})),
 		SchemaVersion: 2,
+		Timeouts:      mountTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
+	r.Timeouts.Update = schema.DefaultTimeout(DefaultMountTimeout)
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.HasChange("instance_profile") && !d.HasChange("cluster_id") {
+			// mounting cluster for the new instance profile is known only during apply
+			if err := d.SetNewComputed("cluster_id"); err != nil {
+				return err
+			}
+		}
+		return validateS3Mount(d)
+	}
 	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		if err := preprocessS3Mount(ctx, d, m); err != nil {
+		if len(d.Get("instance_profiles").([]interface{})) > 0 {
+			return mountS3WithAnyInstanceProfile(ctx, d, m, tpl, r)
+		}
+		if err := preprocessS3Mount(ctx, d, m, r.Schema); err != nil {
 			return diag.FromErr(err)
 		}
 		return mountCreate(tpl, r)(ctx, d, m)
 	}
 	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		if err := preprocessS3Mount(ctx, d, m); err != nil {
+		if skipMountRead(d) {
+			// neither the mounting cluster nor the bucket is looked up
+			return readMountFromState(d)
+		}
+		if isS3MountClusterKnown(d) {
+			if err := preprocessS3Mount(ctx, d, m, r.Schema); err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			// right after import there's neither cluster_id nor instance_profile,
+			// though listing mounts is possible from the default mounting cluster
+			log.Printf("[INFO] Reading /mnt/%s from the default mounting cluster", d.Id())
+		}
+		diags := mountRead(tpl, r)(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		scheme, bucket, prefix := parseS3Source(d.Get("source").(string))
+		if scheme != "" {
+			// bucket mounted with other scheme has to be remounted
+			if err := d.Set("scheme", scheme); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if prefix != "" {
+			log.Printf("[WARN] /mnt/%s is mounted to %s prefix of %s bucket, "+
+				"though only the whole bucket could be mounted", d.Id(), prefix, bucket)
+		}
+		// bucket of the live mount is set both on import and on refresh,
+		// so that the mount of a different bucket is replaced
+		if err := d.Set("s3_bucket_name", bucket); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := readS3MountEncryption(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := preprocessS3Mount(ctx, d, m, r.Schema); err != nil {
+			return diag.FromErr(err)
+		}
+		remount, err := s3MountProfileChanged(ctx, d, m)
+		if err != nil {
 			return diag.FromErr(err)
 		}
-		return mountRead(tpl, r)(ctx, d, m)
+		// encryption is set only when the bucket is mounted
+		remount = remount || d.HasChanges("encryption_type", "kms_key")
+		if !remount {
+			if d.HasChange("verify") {
+				return mountRead(tpl, r)(ctx, d, m)
+			}
+			return nil
+		}
+		// mount keeps credentials of the cluster, that has mounted it
+		return mountRemount(tpl, r)(ctx, d, m)
 	}
 	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		if err := preprocessS3Mount(ctx, d, m); err != nil {
+		if err := preprocessS3Mount(ctx, d, m, r.Schema); err != nil {
 			return diag.FromErr(err)
 		}
 		return mountDelete(tpl, r)(ctx, d, m)
 	}
-	return r
+	return requireMountCloud("databricks_aws_s3_mount", common.CloudAWS, r,
+		func(d *schema.ResourceDiff) bool {
+			return d.Get("instance_profile").(string) != "" ||
+				len(d.Get("instance_profiles").([]interface{})) > 0
+		})
+}
+
+// mountS3WithAnyInstanceProfile mounts the bucket through the mounting cluster of each
+// instance profile in turn, until one of them has access to the bucket
+func mountS3WithAnyInstanceProfile(ctx context.Context, d *schema.ResourceData, m interface{},
+	tpl Mount, r *schema.Resource) diag.Diagnostics {
+	failures := []string{}
+	for _, v := range d.Get("instance_profiles").([]interface{}) {
+		instanceProfile := v.(string)
+		if err := d.Set("effective_instance_profile", instanceProfile); err != nil {
+			return diag.FromErr(err)
+		}
+		// cluster of the previously tried profile
+		if err := d.Set("cluster_id", ""); err != nil {
+			return diag.FromErr(err)
+		}
+		var diags diag.Diagnostics
+		if err := preprocessS3Mount(ctx, d, m, r.Schema); err != nil {
+			diags = diag.FromErr(err)
+		} else {
+			diags = mountCreate(tpl, r)(ctx, d, m)
+		}
+		if !diags.HasError() {
+			return diags
+		}
+		for _, failure := range diags {
+			log.Printf("[WARN] Cannot mount with %s: %s", instanceProfile, failure.Summary)
+			failures = append(failures, fmt.Sprintf(" * %s: %s", instanceProfile, failure.Summary))
+		}
+	}
+	d.SetId("")
+	return diag.Errorf("Cannot mount %s bucket with any of instance profiles:\n%s",
+		d.Get("s3_bucket_name"), strings.Join(failures, "\n"))
 }
 
-func preprocessS3Mount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+// readS3MountEncryption sets encryption of the live mount, if it's configured, so that
+// the bucket is remounted, when encryption type or KMS key differs from configuration
+func readS3MountEncryption(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("encryption_type").(string) == "" && d.Get("kms_key").(string) == "" {
+		return nil
+	}
+	client := m.(*common.DatabricksClient)
+	clusterID, err := getMountingClusterID(ctx, client, d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+	mounts, err := ListMounts(client.CommandExecutor(ctx), clusterID)
+	if err != nil {
+		return err
+	}
+	for _, mount := range mounts {
+		if mount.MountName != d.Id() {
+			continue
+		}
+		encryptionType, kmsKey := parseS3Encryption(mount.EncryptionType)
+		if encryptionType != d.Get("encryption_type").(string) || kmsKey != d.Get("kms_key").(string) {
+			log.Printf("[WARN] /mnt/%s is mounted with %q encryption, that differs from configuration",
+				d.Id(), mount.EncryptionType)
+		}
+		if err = d.Set("encryption_type", encryptionType); err != nil {
+			return err
+		}
+		return d.Set("kms_key", kmsKey)
+	}
+	return nil
+}
+
+// s3MountProfileChanged is true, if the bucket has to be remounted with other instance
+// profile, either set explicitly or attached to the new mounting cluster
+func s3MountProfileChanged(ctx context.Context, d *schema.ResourceData, m interface{}) (bool, error) {
+	if d.HasChange("instance_profile") {
+		return true, nil
+	}
+	if !d.HasChange("cluster_id") {
+		return false, nil
+	}
+	before, after := d.GetChange("cluster_id")
+	clustersAPI := compute.NewClustersAPI(ctx, m)
+	newCluster, err := clustersAPI.Get(after.(string))
+	if err != nil {
+		return false, err
+	}
+	oldCluster, err := clustersAPI.Get(before.(string))
+	if err != nil {
+		log.Printf("[WARN] Cannot get instance profile of previous mounting cluster %s: %s", before, err)
+		return true, nil
+	}
+	return !hasInstanceProfile(newCluster, instanceProfileOf(oldCluster)), nil
+}
+
+func instanceProfileOf(cl compute.ClusterInfo) string {
+	if cl.AwsAttributes == nil {
+		return ""
+	}
+	return cl.AwsAttributes.InstanceProfileArn
+}
+
+// isS3MountClusterKnown is false only for imported resources, where
+// the only known attribute is mount name
+func isS3MountClusterKnown(d *schema.ResourceData) bool {
+	return d.Get("cluster_id").(string) != "" ||
+		d.Get("instance_profile").(string) != "" ||
+		len(d.Get("instance_profiles").([]interface{})) > 0 ||
+		len(d.Get("cluster").([]interface{})) > 0
+}
+
+// s3MountInstanceProfile returns instance profile for mounting, either given explicitly
+// or through the custom mounting cluster, and checks that mounting is possible at all
+func s3MountInstanceProfile(clusterID, instanceProfile string, cluster *MountingCluster) (string, error) {
+	instanceProfile, err := mergeS3MountInstanceProfile(instanceProfile, cluster)
+	if err != nil {
+		return "", err
+	}
+	if clusterID == "" && instanceProfile == "" {
+		return "", fmt.Errorf("Either cluster_id or instance_profile must be specified")
+	}
+	return instanceProfile, nil
+}
+
+// mergeS3MountInstanceProfile returns instance_profile or the one from custom mounting
+// cluster specification, that must be the same, if both are set
+func mergeS3MountInstanceProfile(instanceProfile string, cluster *MountingCluster) (string, error) {
+	if cluster == nil || cluster.AwsAttributes == nil {
+		return instanceProfile, nil
+	}
+	clusterProfile := cluster.AwsAttributes.InstanceProfileArn
+	if instanceProfile != "" && clusterProfile != "" && instanceProfile != clusterProfile {
+		return "", fmt.Errorf("instance_profile and cluster.aws_attributes.instance_profile_arn must be the same")
+	}
+	if instanceProfile == "" {
+		instanceProfile = clusterProfile
+	}
+	return instanceProfile, nil
+}
+
+// validateS3Mount performs static validation of S3 mount configuration during plan,
+// without any API calls or commands. Unknown values are left for apply.
+func validateS3Mount(d *schema.ResourceDiff) error {
+	clusterProfileKey := "cluster.0.aws_attributes.0.instance_profile_arn"
+	if !d.NewValueKnown("instance_profile") || !d.NewValueKnown(clusterProfileKey) {
+		return nil
+	}
+	var cluster *MountingCluster
+	clusterProfile := d.Get(clusterProfileKey).(string)
+	if clusterProfile != "" {
+		cluster = &MountingCluster{
+			AwsAttributes: &compute.AwsAttributes{
+				InstanceProfileArn: clusterProfile,
+			},
+		}
+	}
+	// presence of cluster_id or instance profile is checked with AtLeastOneOf
+	instanceProfile, err := mergeS3MountInstanceProfile(d.Get("instance_profile").(string), cluster)
+	if err != nil {
+		return err
+	}
+	if d.Get("kms_key").(string) != "" && d.NewValueKnown("encryption_type") &&
+		d.Get("encryption_type").(string) != S3EncryptionTypeKMS {
+		return fmt.Errorf("kms_key requires encryption_type to be %s", S3EncryptionTypeKMS)
+	}
+	if instanceProfile != "" {
+		_, err = mountingClusterName(instanceProfile)
+		return err
+	}
+	if !d.NewValueKnown("instance_profiles") {
+		return nil
+	}
+	for _, v := range d.Get("instance_profiles").([]interface{}) {
+		if _, err = mountingClusterName(v.(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func preprocessS3Mount(ctx context.Context, d *schema.ResourceData, m interface{},
+	s map[string]*schema.Schema) error {
+	var mc awsS3MountingCluster
+	if err := common.DataToStructPointer(d, s, &mc); err != nil {
+		return err
+	}
 	clusterID := d.Get("cluster_id").(string)
 	instanceProfile := d.Get("instance_profile").(string)
-	if clusterID == "" && instanceProfile == "" {
-		return fmt.Errorf("Either cluster_id or instance_profile must be specified")
+	if len(d.Get("instance_profiles").([]interface{})) > 0 {
+		// one of the listed profiles, that has access to the bucket
+		instanceProfile = d.Get("effective_instance_profile").(string)
+	}
+	instanceProfile, err := s3MountInstanceProfile(clusterID, instanceProfile, mc.Cluster)
+	if err != nil {
+		return err
 	}
 	clustersAPI := compute.NewClustersAPI(ctx, m)
 	if clusterID != "" {
 		clusterInfo, err := clustersAPI.Get(clusterID)
 		if err != nil {
-			return err
+			return fmt.Errorf("Mounting cluster %s is unavailable: %w", clusterID, err)
 		}
-		if clusterInfo.AwsAttributes == nil {
-			return fmt.Errorf("Cluster %s must have AWS attributes", clusterID)
+		// otherwise mount command fails only at runtime with obscure error
+		if clusterInfo.AwsAttributes == nil ||
+			clusterInfo.AwsAttributes.InstanceProfileArn == "" {
+			return fmt.Errorf("Cluster %s has no instance profile attached", clusterID)
 		}
-		if len(clusterInfo.AwsAttributes.InstanceProfileArn) == 0 {
-			return fmt.Errorf("Cluster %s must have EC2 instance profile attached", clusterID)
+		if instanceProfile == "" {
+			return d.Set("effective_instance_profile", clusterInfo.AwsAttributes.InstanceProfileArn)
 		}
 	}
 	if instanceProfile != "" {
-		cluster, err := GetOrCreateMountingClusterWithInstanceProfile(clustersAPI, instanceProfile)
+		cluster, err := getOrCreateMountingCluster(ctx, m.(*common.DatabricksClient),
+			instanceProfile, mc.Cluster)
 		if err != nil {
 			return err
 		}
+		if err = d.Set("effective_instance_profile", instanceProfile); err != nil {
+			return err
+		}
 		return d.Set("cluster_id", cluster.ClusterID)
 	}
 	return nil
 }
 
-// GetOrCreateMountingClusterWithInstanceProfile ...
+// MountingClusterInstanceProfileTag is the custom tag key of clusters, created
+// for mounting with instance profile. Tag value is the instance profile ARN.
+const MountingClusterInstanceProfileTag = "TerraformMountInstanceProfile"
+
+// MountingClusterAwsAttributes are defaults of aws_attributes for clusters, that are
+// created for mounting with instance profile. Zone is picked by Databricks, so that the
+// cluster launches in the zone with available capacity. EBS volumes are not attached,
+// unless the volume count is set, as default node types have local disks.
+var MountingClusterAwsAttributes = compute.AwsAttributes{
+	Availability: compute.AwsAvailabilitySpot,
+	ZoneID:       "auto",
+}
+
+// mountingClusterAwsAttributes returns aws_attributes of the mounting cluster, where
+// empty fields of the custom ones are taken from MountingClusterAwsAttributes. Clusters
+// from instance pools get only custom attributes, as the pool defines availability,
+// zone and volumes of its instances.
+func mountingClusterAwsAttributes(custom *compute.AwsAttributes,
+	instancePoolID, instanceProfile string) *compute.AwsAttributes {
+	var awsAttributes compute.AwsAttributes
+	if custom != nil {
+		awsAttributes = *custom
+	}
+	if instancePoolID == "" {
+		defaults := MountingClusterAwsAttributes
+		if awsAttributes.Availability == "" {
+			awsAttributes.Availability = defaults.Availability
+		}
+		if awsAttributes.ZoneID == "" {
+			awsAttributes.ZoneID = defaults.ZoneID
+		}
+		if awsAttributes.FirstOnDemand == 0 {
+			awsAttributes.FirstOnDemand = defaults.FirstOnDemand
+		}
+		if awsAttributes.SpotBidPricePercent == 0 {
+			awsAttributes.SpotBidPricePercent = defaults.SpotBidPricePercent
+		}
+		if awsAttributes.EbsVolumeCount == 0 {
+			// volume type and size make sense only together with count
+			awsAttributes.EbsVolumeType = defaults.EbsVolumeType
+			awsAttributes.EbsVolumeCount = defaults.EbsVolumeCount
+			awsAttributes.EbsVolumeSize = defaults.EbsVolumeSize
+		}
+	}
+	awsAttributes.InstanceProfileArn = instanceProfile
+	return &awsAttributes
+}
+
+// GetOrCreateTaggedMountingCluster returns running cluster for mounting with
+// the registered instance profile, that is tagged with default cluster tags of the client
+func GetOrCreateTaggedMountingCluster(ctx context.Context,
+	client *common.DatabricksClient, instanceProfile string) (i compute.ClusterInfo, err error) {
+	return getOrCreateMountingCluster(ctx, client, instanceProfile, nil)
+}
+
+// GetOrCreateMountingClusterWithInstanceProfile returns running cluster for mounting with
+// the instance profile, that is neither tagged nor checked for registration in the workspace.
+//
+// Deprecated: use GetOrCreateTaggedMountingCluster instead.
 func GetOrCreateMountingClusterWithInstanceProfile(
 	clustersAPI compute.ClustersAPI, instanceProfile string) (i compute.ClusterInfo, err error) {
 	ia, err := arn.Parse(instanceProfile)
@@ -140,7 +575,146 @@ func GetOrCreateMountingClusterWithInstanceProfile(
 		AutoterminationMinutes: 10,
 		AwsAttributes: &compute.AwsAttributes{
 			InstanceProfileArn: instanceProfile,
-			Availability:       "SPOT",
+			Availability:       compute.AwsAvailabilitySpot,
 		},
 	})
 }
+
+// mountingClusterName returns name of the cluster for mounting with instance profile
+func mountingClusterName(instanceProfile string) (string, error) {
+	ia, err := arn.Parse(instanceProfile)
+	if err != nil {
+		return "", err
+	}
+	instanceProfileParts := strings.Split(ia.Resource, "/")
+	if len(instanceProfileParts) != 2 {
+		return "", fmt.Errorf("Should have gotten two parts: %v", instanceProfileParts)
+	}
+	if instanceProfileParts[0] != "instance-profile" {
+		return "", fmt.Errorf("Not an instance profile ARN: %s", instanceProfile)
+	}
+	return fmt.Sprintf("terraform-mount-%s", instanceProfileParts[1]), nil
+}
+
+// getOrCreateMountingCluster creates cluster with instance profile, where defaults
+// could be overridden by optional custom specification. Default tags of the client
+// are merged with the instance profile tag, which always takes precedence.
+func getOrCreateMountingCluster(ctx context.Context, client *common.DatabricksClient,
+	instanceProfile string, custom *MountingCluster) (i compute.ClusterInfo, err error) {
+	clusterName, err := mountingClusterName(instanceProfile)
+	if err != nil {
+		return i, err
+	}
+	clustersAPI := compute.NewClustersAPI(ctx, client)
+	defaultTags := client.DefaultClusterTags
+	if custom == nil {
+		custom = &MountingCluster{}
+	}
+	cluster := compute.Cluster{
+		NumWorkers:             1,
+		ClusterName:            clusterName,
+		SparkVersion:           custom.SparkVersion,
+		NodeTypeID:             custom.NodeTypeID,
+		AutoterminationMinutes: 10,
+	}
+	if cluster.SparkVersion == "" {
+		cluster.SparkVersion = clustersAPI.LatestSparkVersionOrDefault(
+			compute.SparkVersionRequest{
+				Latest:          true,
+				LongTermSupport: true,
+			})
+	}
+	if custom.InstancePoolID != "" {
+		if custom.NodeTypeID != "" {
+			return i, fmt.Errorf("instance_pool_id and node_type_id cannot be both set")
+		}
+		// node type and availability are defined by the instance pool
+		cluster.InstancePoolID = custom.InstancePoolID
+	} else if cluster.NodeTypeID == "" {
+		cluster.NodeTypeID = mountingClusterNodeType(clustersAPI)
+	}
+	cluster.AwsAttributes = mountingClusterAwsAttributes(
+		custom.AwsAttributes, cluster.InstancePoolID, instanceProfile)
+	instanceProfileTags := map[string]string{
+		MountingClusterInstanceProfileTag: instanceProfile,
+	}
+	cluster.CustomTags = mergeClusterTags(defaultTags, instanceProfileTags)
+	clusters, err := clustersAPI.List()
+	if err != nil {
+		return i, err
+	}
+	tagged := findTaggedMountingCluster(clusters, instanceProfile)
+	if tagged != nil {
+		log.Printf("[INFO] Reusing %s mounting cluster %s tagged with %s",
+			tagged.State, tagged.ClusterID, instanceProfile)
+		if tagged.IsRunningOrResizing() {
+			return *tagged, nil
+		}
+		// terminating cluster is started once it's terminated
+		return clustersAPI.StartAndGetInfo(tagged.ClusterID)
+	}
+	for _, cl := range clusters {
+		if cl.ClusterName != clusterName || hasInstanceProfile(cl, instanceProfile) {
+			continue
+		}
+		// cluster with the same name would be reused as is, so it's edited in place
+		// to have the instance profile attached instead of creating a new one
+		if err = checkInstanceProfileRegistered(ctx, client, instanceProfile); err != nil {
+			return i, err
+		}
+		log.Printf("[INFO] Attaching %s to mounting cluster %s", instanceProfile, cl.ClusterID)
+		cluster.ClusterID = cl.ClusterID
+		// edit replaces the whole specification, so existing tags are kept
+		cluster.CustomTags = mergeClusterTags(cl.CustomTags, defaultTags, instanceProfileTags)
+		i, err = clustersAPI.Edit(cluster)
+		if err != nil {
+			return i, err
+		}
+		if i.IsRunningOrResizing() {
+			return i, nil
+		}
+		return clustersAPI.StartAndGetInfo(cl.ClusterID)
+	}
+	if err = checkInstanceProfileRegistered(ctx, client, instanceProfile); err != nil {
+		return i, err
+	}
+	return clustersAPI.GetOrCreateRunningCluster(clusterName, cluster)
+}
+
+// checkInstanceProfileRegistered fails fast, if the instance profile is not added
+// to the workspace, as the cluster launch with it fails only after minutes
+func checkInstanceProfileRegistered(ctx context.Context, client *common.DatabricksClient,
+	instanceProfile string) error {
+	_, err := identity.NewInstanceProfilesAPI(ctx, client).Read(instanceProfile)
+	if e, ok := err.(common.APIError); ok && e.IsMissing() {
+		return fmt.Errorf("Instance profile %s is not registered in the workspace, "+
+			"please add it with databricks_instance_profile", instanceProfile)
+	}
+	return err
+}
+
+func hasInstanceProfile(cl compute.ClusterInfo, instanceProfile string) bool {
+	return cl.AwsAttributes != nil && cl.AwsAttributes.InstanceProfileArn == instanceProfile
+}
+
+// findTaggedMountingCluster returns cluster, that was previously created for mounting
+// with the given instance profile, or nil if there is none. Non-terminated clusters are
+// preferred, though terminated one is returned as well, so that it's started again.
+func findTaggedMountingCluster(clusters []compute.ClusterInfo,
+	instanceProfile string) *compute.ClusterInfo {
+	var terminated *compute.ClusterInfo
+	for i, cl := range clusters {
+		if cl.CustomTags[MountingClusterInstanceProfileTag] != instanceProfile {
+			continue
+		}
+		if cl.State == compute.ClusterStateTerminated ||
+			cl.State == compute.ClusterStateTerminating {
+			if terminated == nil {
+				terminated = &clusters[i]
+			}
+			continue
+		}
+		return &clusters[i]
+	}
+	return terminated
+}
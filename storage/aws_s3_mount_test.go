@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/internal"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,6 +24,22 @@ var _ Mount = (*AWSIamMount)(nil)
 const testS3BucketName = "test-s3-bucket"
 const testS3BucketPath = "s3a://" + testS3BucketName
 
+// registeredInstanceProfiles returns fixture listing instance profiles of the workspace
+func registeredInstanceProfiles(instanceProfiles ...string) qa.HTTPFixture {
+	list := identity.InstanceProfileList{}
+	for _, instanceProfile := range instanceProfiles {
+		list.InstanceProfiles = append(list.InstanceProfiles, identity.InstanceProfileInfo{
+			InstanceProfileArn: instanceProfile,
+		})
+	}
+	return qa.HTTPFixture{
+		Method:       "GET",
+		Resource:     "/api/2.0/instance-profiles/list",
+		ReuseRequest: true,
+		Response:     list,
+	}
+}
+
 func TestResourceAwsS3MountCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -37,86 +55,1306 @@ func TestResourceAwsS3MountCreate(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceAWSS3Mount(),
-		CommandMock: func(commandStr string) (string, error) {
-			trunc := internal.TrimLeadingWhitespace(commandStr)
-			t.Logf("Received command:\n%s", trunc)
-			if strings.HasPrefix(trunc, "def safe_mount") {
-				assert.Contains(t, trunc, testS3BucketPath) // bucketname
-				assert.Contains(t, trunc, `{}`)             // empty brackets for empty config
-			}
-			assert.Contains(t, trunc, "/mnt/this_mount")
-			return testS3BucketPath, nil
-		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			t.Logf("Received command:\n%s", trunc)
+			if strings.HasPrefix(trunc, "def safe_mount") {
+				assert.Contains(t, trunc, testS3BucketPath) // bucketname
+				assert.Contains(t, trunc, `{}`)             // empty brackets for empty config
+			}
+			assert.Contains(t, trunc, "/mnt/this_mount")
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err) // TODO: global search-replace for NoError
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, "/mnt/this_mount", d.Get("mount_point"))
+	assert.Equal(t, "s3://"+testS3BucketName, d.Get("url"))
+}
+
+func TestResourceAwsS3MountCreate_Schemes(t *testing.T) {
+	for _, scheme := range []string{"s3a", "s3n", "s3"} {
+		source := scheme + "://" + testS3BucketName
+		d, err := qa.ResourceFixture{
+			Fixtures: []qa.HTTPFixture{
+				{
+					Method:       "GET",
+					ReuseRequest: true,
+					Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+					Response: compute.ClusterInfo{
+						State: compute.ClusterStateRunning,
+						AwsAttributes: &compute.AwsAttributes{
+							InstanceProfileArn: "abc",
+						},
+					},
+				},
+			},
+			Resource: ResourceAWSS3Mount(),
+			CommandMock: func(commandStr string) (string, error) {
+				trunc := internal.TrimLeadingWhitespace(commandStr)
+				if strings.HasPrefix(trunc, "def safe_mount") {
+					assert.Contains(t, trunc, fmt.Sprintf(`safe_mount("/mnt/this_mount", "%s"`, source))
+				}
+				return testMountListing("this_mount", source), nil
+			},
+			HCL: fmt.Sprintf(`
+			cluster_id = "this_cluster"
+			mount_name = "this_mount"
+			s3_bucket_name = "%s"
+			scheme = "%s"`, testS3BucketName, scheme),
+			Create: true,
+		}.Apply(t)
+		require.NoError(t, err, err)
+		assert.Equal(t, source, d.Get("source"), scheme)
+		assert.Equal(t, scheme, d.Get("scheme"), scheme)
+	}
+}
+
+func TestResourceAwsS3MountCreate_InvalidScheme(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		cluster_id = "this_cluster"
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		scheme = "gs"`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [scheme] expected scheme to be one of [s3a s3n s3], got gs")
+}
+
+func TestResourceAwsS3MountCreate_nothing_specified(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		State: map[string]interface{}{
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.EqualError(t, err, "Invalid config supplied. [cluster_id] AtLeastOne")
+}
+
+func TestResourceAwsS3MountPlan_NothingSpecified(t *testing.T) {
+	recorder := &qa.CommandRecorder{}
+	qa.ResourceFixture{
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"`,
+		Plan: true,
+	}.ExpectError(t, "Invalid config supplied. [cluster_id] AtLeastOne")
+	assert.Equal(t, 0, recorder.Executed(""))
+}
+
+func TestResourceAwsS3MountPlan_ClusterInstanceProfile(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		cluster {
+			aws_attributes {
+				instance_profile_arn = "arn:aws:iam::1234567:instance-profile/s3-access"
+			}
+		}`,
+		Plan: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceAwsS3MountCreate_invalid_arn(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		State: map[string]interface{}{
+			"mount_name":       "this_mount",
+			"s3_bucket_name":   testS3BucketName,
+			"instance_profile": "this_mount",
+		},
+		Create: true,
+	}.Apply(t)
+	require.EqualError(t, err, "arn: invalid prefix")
+}
+
+func TestResourceAwsS3MountCreate_InstanceProfileWithoutName(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			assert.Fail(t, "No commands should be executed during plan")
+			return "", nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile"`,
+		Create: true,
+	}.ExpectError(t, "Should have gotten two parts: [instance-profile]")
+}
+
+func TestResourceAwsS3MountCreate_RoleInsteadOfInstanceProfile(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:role/s3-access"`,
+		Create: true,
+	}.ExpectError(t, "Not an instance profile ARN: arn:aws:iam::1234567:role/s3-access")
+}
+
+func TestResourceAwsS3MountCreate_InvalidMountName(t *testing.T) {
+	for _, name := range []string{
+		`this"mount`,
+		`this_mount")\nimport os`,
+		"this\nmount",
+		"/this_mount",
+		"this_mount/",
+		"this mount",
+	} {
+		qa.ResourceFixture{
+			Resource: ResourceAWSS3Mount(),
+			CommandMock: func(commandStr string) (string, error) {
+				assert.Fail(t, "No commands should be executed for invalid names")
+				return "", nil
+			},
+			State: map[string]interface{}{
+				"mount_name":       name,
+				"s3_bucket_name":   testS3BucketName,
+				"instance_profile": "arn:aws:iam::1234567:instance-profile/s3-access",
+			},
+			Create: true,
+		}.ExpectError(t, "Invalid config supplied. [mount_name] invalid value for mount_name "+
+			"(must contain only alphanumerics, dashes, underscores and slashes between nested names)")
+	}
+}
+
+func TestResourceAwsS3MountDiff_NoAPICalls(t *testing.T) {
+	r := ResourceAWSS3Mount()
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"mount_name":       "this_mount",
+		"s3_bucket_name":   testS3BucketName,
+		"instance_profile": "this_mount",
+	}), nil)
+	assert.EqualError(t, err, "arn: invalid prefix")
+
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"mount_name":       "this_mount",
+		"s3_bucket_name":   testS3BucketName,
+		"instance_profile": "arn:aws:iam::1234567:instance-profile/a",
+	}), nil)
+	assert.NoError(t, err)
+}
+
+func TestResourceAwsS3MountCreate_ClusterWithoutAwsAttributes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID: "this_cluster",
+					State:     compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.EqualError(t, err, "Cluster this_cluster has no instance profile attached")
+}
+
+func TestResourceAwsS3MountCreate_ClusterWithoutInstanceProfile(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID:     "this_cluster",
+					State:         compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.EqualError(t, err, "Cluster this_cluster has no instance profile attached")
+}
+
+func TestResourceAwsS3MountCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return "", errors.New("Some error")
+		},
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.EqualError(t, err, "Some error")
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "", d.Get("source"))
+}
+
+func TestResourceAwsS3MountCreate_CustomCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/list",
+				ReuseRequest: true,
+				Response:     compute.ClusterList{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: compute.Cluster{
+					NumWorkers:             1,
+					ClusterName:            "terraform-mount-s3-access",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "m5d.large",
+					AutoterminationMinutes: 10,
+					AwsAttributes: &compute.AwsAttributes{
+						Availability:       "ON_DEMAND",
+						ZoneID:             "us-east-1a",
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+					CustomTags: map[string]string{
+						MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+				},
+				Response: compute.ClusterID{
+					ClusterID: "bcd",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: compute.ClusterInfo{
+					ClusterID: "bcd",
+					State:     compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "m5d.large"
+			aws_attributes {
+				availability = "ON_DEMAND"
+				zone_id = "us-east-1a"
+			}
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "bcd", d.Get("cluster_id"))
+}
+
+func TestResourceAwsS3MountCreate_UnregisteredInstanceProfile(t *testing.T) {
+	// there are no fixtures for cluster creation, so test fails, if it's attempted
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/list",
+				ReuseRequest: true,
+				Response:     compute.ClusterList{},
+			},
+			registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/other"),
+		},
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "m5d.large"
+		}`,
+		Create: true,
+	}.ExpectError(t, "Instance profile arn:aws:iam::1234567:instance-profile/s3-access "+
+		"is not registered in the workspace, please add it with databricks_instance_profile")
+}
+
+func TestResourceAwsS3MountCreate_ReusesTaggedCluster(t *testing.T) {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{
+						{
+							ClusterID:   "terminated",
+							ClusterName: "renamed-mounter",
+							State:       compute.ClusterStateTerminated,
+							CustomTags: map[string]string{
+								MountingClusterInstanceProfileTag: instanceProfile,
+							},
+						},
+						{
+							ClusterID:   "other",
+							ClusterName: "other-mounter",
+							State:       compute.ClusterStateRunning,
+							CustomTags: map[string]string{
+								MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/other",
+							},
+						},
+						{
+							ClusterID:   "tagged",
+							ClusterName: "renamed-mounter",
+							State:       compute.ClusterStateRunning,
+							CustomTags: map[string]string{
+								MountingClusterInstanceProfileTag: instanceProfile,
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=tagged",
+				Response: compute.ClusterInfo{
+					ClusterID: "tagged",
+					State:     compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: instanceProfile,
+					},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "m5d.large"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "tagged", d.Get("cluster_id"))
+}
+
+// taggedMountingClusters returns fixtures of running mounting clusters for every
+// instance profile, where cluster id is the name of the instance profile
+func taggedMountingClusters(instanceProfiles ...string) []qa.HTTPFixture {
+	clusters := []compute.ClusterInfo{}
+	fixtures := []qa.HTTPFixture{}
+	for _, instanceProfile := range instanceProfiles {
+		cluster := compute.ClusterInfo{
+			ClusterID: strings.Split(instanceProfile, "/")[1],
+			State:     compute.ClusterStateRunning,
+			AwsAttributes: &compute.AwsAttributes{
+				InstanceProfileArn: instanceProfile,
+			},
+			CustomTags: map[string]string{
+				MountingClusterInstanceProfileTag: instanceProfile,
+			},
+		}
+		clusters = append(clusters, cluster)
+		fixtures = append(fixtures, qa.HTTPFixture{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=" + cluster.ClusterID,
+			Response:     cluster,
+		})
+	}
+	return append(fixtures,
+		qa.HTTPFixture{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list",
+			Response: compute.ClusterList{
+				Clusters: clusters,
+			},
+		},
+		qa.HTTPFixture{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/spark-versions",
+			Response:     compute.SparkVersionsList{},
+		},
+		qa.HTTPFixture{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list-node-types",
+			Response:     compute.NodeTypeList{},
+		})
+}
+
+func TestResourceAwsS3MountCreate_InstanceProfiles(t *testing.T) {
+	mounts := 0
+	d, err := qa.ResourceFixture{
+		Fixtures: taggedMountingClusters(
+			"arn:aws:iam::1234567:instance-profile/first",
+			"arn:aws:iam::1234567:instance-profile/second"),
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			if !strings.Contains(commandStr, "safe_mount") {
+				return testMountListing("this_mount", testS3BucketPath), nil
+			}
+			mounts++
+			if mounts == 1 {
+				return "", errors.New("com.amazonaws.services.s3.model.AmazonS3Exception: Access Denied")
+			}
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profiles = [
+			"arn:aws:iam::1234567:instance-profile/first",
+			"arn:aws:iam::1234567:instance-profile/second",
+		]`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 2, mounts)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "second", d.Get("cluster_id"))
+	assert.Equal(t, "arn:aws:iam::1234567:instance-profile/second", d.Get("effective_instance_profile"))
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+}
+
+func TestResourceAwsS3MountCreate_InstanceProfilesAllFail(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: taggedMountingClusters(
+			"arn:aws:iam::1234567:instance-profile/first",
+			"arn:aws:iam::1234567:instance-profile/second"),
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return "", errors.New("com.amazonaws.services.s3.model.AmazonS3Exception: Access Denied")
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profiles = [
+			"arn:aws:iam::1234567:instance-profile/first",
+			"arn:aws:iam::1234567:instance-profile/second",
+		]`,
+		Create: true,
+	}.ExpectError(t, "Cannot mount test-s3-bucket bucket with any of instance profiles:\n"+
+		" * arn:aws:iam::1234567:instance-profile/first: Access Denied\n"+
+		" * arn:aws:iam::1234567:instance-profile/second: Access Denied")
+}
+
+func TestResourceAwsS3MountRead_InstanceProfiles(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: taggedMountingClusters(
+			"arn:aws:iam::1234567:instance-profile/first",
+			"arn:aws:iam::1234567:instance-profile/second"),
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		State: map[string]interface{}{
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+			"instance_profiles": []interface{}{
+				"arn:aws:iam::1234567:instance-profile/first",
+				"arn:aws:iam::1234567:instance-profile/second",
+			},
+			"effective_instance_profile": "arn:aws:iam::1234567:instance-profile/second",
+			"cluster_id":                 "second",
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "second", d.Get("cluster_id"))
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+}
+
+func TestResourceAwsS3MountDiff_InstanceProfilesConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/first"
+		instance_profiles = ["arn:aws:iam::1234567:instance-profile/second"]`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. instance_profiles: conflicts with instance_profile")
+}
+
+func TestResourceAwsS3MountCreate_EditsReusedClusterWithoutInstanceProfile(t *testing.T) {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	reused := compute.ClusterInfo{
+		ClusterID:    "reused",
+		ClusterName:  "terraform-mount-s3-access",
+		SparkVersion: "7.3.x-scala2.12",
+		NodeTypeID:   "m5d.large",
+		State:        compute.ClusterStateTerminated,
+	}
+	attached := reused
+	attached.State = compute.ClusterStateRunning
+	attached.AwsAttributes = &compute.AwsAttributes{
+		InstanceProfileArn: instanceProfile,
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list",
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{reused},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=reused",
+				Response: reused,
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/edit",
+				ExpectedRequest: compute.Cluster{
+					ClusterID:              "reused",
+					ClusterName:            "terraform-mount-s3-access",
+					NumWorkers:             1,
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "m5d.large",
+					AutoterminationMinutes: 10,
+					AwsAttributes: &compute.AwsAttributes{
+						Availability:       "SPOT",
+						ZoneID:             "auto",
+						InstanceProfileArn: instanceProfile,
+					},
+					CustomTags: map[string]string{
+						MountingClusterInstanceProfileTag: instanceProfile,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=reused",
+				Response: reused,
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/start",
+				ExpectedRequest: compute.ClusterID{
+					ClusterID: "reused",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=reused",
+				Response:     attached,
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "m5d.large"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "reused", d.Get("cluster_id"))
+}
+
+func TestGetOrCreateMountingCluster_DefaultTags(t *testing.T) {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list",
+			Response:     compute.ClusterList{},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: compute.Cluster{
+				NumWorkers:             1,
+				ClusterName:            "terraform-mount-s3-access",
+				SparkVersion:           "7.3.x-scala2.12",
+				NodeTypeID:             "m5d.large",
+				AutoterminationMinutes: 10,
+				AwsAttributes: &compute.AwsAttributes{
+					Availability:       "SPOT",
+					ZoneID:             "auto",
+					InstanceProfileArn: instanceProfile,
+				},
+				CustomTags: map[string]string{
+					"CostCenter":                      "data",
+					MountingClusterInstanceProfileTag: instanceProfile,
+				},
+			},
+			Response: compute.ClusterID{
+				ClusterID: "bcd",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: compute.ClusterInfo{
+				ClusterID: "bcd",
+				State:     compute.ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.DefaultClusterTags = map[string]string{
+			"CostCenter": "data",
+			// tags required for mounting cannot be overridden
+			MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/other",
+		}
+		clusterInfo, err := getOrCreateMountingCluster(ctx, client,
+			instanceProfile, &MountingCluster{
+				SparkVersion: "7.3.x-scala2.12",
+				NodeTypeID:   "m5d.large",
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "bcd", clusterInfo.ClusterID)
+	})
+}
+
+func TestGetOrCreateMountingCluster_KeepsTagsOfReusedCluster(t *testing.T) {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	reused := compute.ClusterInfo{
+		ClusterID:   "reused",
+		ClusterName: "terraform-mount-s3-access",
+		State:       compute.ClusterStateRunning,
+		CustomTags: map[string]string{
+			"Owner":      "someone@example.com",
+			"CostCenter": "legacy",
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list",
+			Response: compute.ClusterList{
+				Clusters: []compute.ClusterInfo{reused},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=reused",
+			Response: reused,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/edit",
+			ExpectedRequest: compute.Cluster{
+				ClusterID:              "reused",
+				ClusterName:            "terraform-mount-s3-access",
+				NumWorkers:             1,
+				SparkVersion:           "7.3.x-scala2.12",
+				NodeTypeID:             "m5d.large",
+				AutoterminationMinutes: 10,
+				AwsAttributes: &compute.AwsAttributes{
+					Availability:       "SPOT",
+					ZoneID:             "auto",
+					InstanceProfileArn: instanceProfile,
+				},
+				CustomTags: map[string]string{
+					"Owner":                           "someone@example.com",
+					"CostCenter":                      "data",
+					MountingClusterInstanceProfileTag: instanceProfile,
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=reused",
+			Response:     reused,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.DefaultClusterTags = map[string]string{
+			"CostCenter": "data",
+		}
+		clusterInfo, err := getOrCreateMountingCluster(ctx, client,
+			instanceProfile, &MountingCluster{
+				SparkVersion: "7.3.x-scala2.12",
+				NodeTypeID:   "m5d.large",
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "reused", clusterInfo.ClusterID)
+	})
+}
+
+func TestResourceAwsS3MountCreate_TerminatesMountingCluster(t *testing.T) {
+	TerminateMountingClusterAfterUse = true
+	defer func() {
+		TerminateMountingClusterAfterUse = false
+	}()
+	mountingCluster := compute.ClusterInfo{
+		ClusterID:   "bcd",
+		ClusterName: "terraform-mount-s3-access",
+		State:       compute.ClusterStateRunning,
+		AwsAttributes: &compute.AwsAttributes{
+			InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+		},
+		CustomTags: map[string]string{
+			MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/s3-access",
+		},
+	}
+	terminatedCluster := mountingCluster
+	terminatedCluster.State = compute.ClusterStateTerminated
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: compute.SparkVersionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: compute.NodeTypeList{},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/list",
+				ReuseRequest: true,
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{mountingCluster},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: mountingCluster,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: mountingCluster,
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/delete",
+				ExpectedRequest: compute.ClusterID{
+					ClusterID: "bcd",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: terminatedCluster,
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "bcd", d.Get("cluster_id"))
+}
+
+func TestResourceAwsS3MountCreate_KeepsUserCluster(t *testing.T) {
+	TerminateMountingClusterAfterUse = true
+	defer func() {
+		TerminateMountingClusterAfterUse = false
+	}()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID:   "this_cluster",
+					ClusterName: "Shared Autoscaling",
+					State:       compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+}
+
+func TestResourceAwsS3MountCreate_CustomClusterConflictsWithClusterID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		cluster_id = "abc"
+		cluster {
+			node_type_id = "m5d.large"
+		}`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. cluster: conflicts with cluster_id")
+}
+
+func TestResourceAwsS3MountCreate_InstancePool(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			registeredInstanceProfiles("arn:aws:iam::1234567:instance-profile/s3-access"),
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/list",
+				ReuseRequest: true,
+				Response:     compute.ClusterList{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: compute.Cluster{
+					NumWorkers:             1,
+					ClusterName:            "terraform-mount-s3-access",
+					SparkVersion:           "7.3.x-scala2.12",
+					InstancePoolID:         "pool",
+					AutoterminationMinutes: 10,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+					CustomTags: map[string]string{
+						MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+				},
+				Response: compute.ClusterID{
+					ClusterID: "bcd",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: compute.ClusterInfo{
+					ClusterID:      "bcd",
+					State:          compute.ClusterStateRunning,
+					InstancePoolID: "pool",
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+				},
+			},
+		},
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", testS3BucketPath), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			spark_version = "7.3.x-scala2.12"
+			instance_pool_id = "pool"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "bcd", d.Get("cluster_id"))
+}
+
+func TestResourceAwsS3MountCreate_InstancePoolConflictsWithNodeType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		cluster {
+			node_type_id = "m5d.large"
+			instance_pool_id = "pool"
+		}`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. cluster.0.instance_pool_id: "+
+		"conflicts with cluster.0.node_type_id")
+}
+
+func TestResourceAwsS3MountCreate_CustomClusterProfileMismatch(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/a"
+		cluster {
+			aws_attributes {
+				instance_profile_arn = "arn:aws:iam::1234567:instance-profile/b"
+			}
+		}`,
+		Create: true,
+	}.ExpectError(t, "instance_profile and cluster.aws_attributes.instance_profile_arn must be the same")
+}
+
+func TestResourceAwsS3MountRead(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, 1, recorder.Executed("dbutils.fs.mounts()"))
+	assert.Equal(t, 1, recorder.Executed(`mount.mountPoint == "/mnt/this_mount"`))
+}
+
+func TestResourceAwsS3MountRead_TrailingSlashHasNoDiff(t *testing.T) {
+	r := ResourceAWSS3Mount()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource: r,
+		CommandMock: func(commandStr string) (string, error) {
+			return testMountListing("this_mount", "S3A://"+testS3BucketName+"/"), nil
+		},
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":     "this_cluster",
+		"mount_name":     "this_mount",
+		"s3_bucket_name": testS3BucketName,
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "Unexpected diff: %v", diff)
+}
+
+func TestResourceAwsS3MountRead_BucketDrift(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		State: map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": "renamed-bucket",
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, testS3BucketName, d.Get("s3_bucket_name"), "live bucket has to be read back")
+	assert.Equal(t, 1, recorder.Executed(`mount.mountPoint == "/mnt/this_mount"`))
+}
+
+func TestResourceAwsS3MountRead_EncryptionDrift(t *testing.T) {
+	r := ResourceAWSS3Mount()
+	recorder := &qa.CommandRecorder{
+		Responses: []qa.CommandResponse{
+			{Result: testMountListing("this_mount", testS3BucketPath)},
+			{Result: `[{"mount_point": "/mnt/this_mount", "source": "` + testS3BucketPath +
+				`", "encryption_type": "sse-kms:arn:aws:kms:us-east-1:1234567:key/other"}]`},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        r,
+		CommandRecorder: recorder,
+		State: map[string]interface{}{
+			"cluster_id":      "this_cluster",
+			"mount_name":      "this_mount",
+			"s3_bucket_name":  testS3BucketName,
+			"encryption_type": "sse-kms",
+			"kms_key":         "arn:aws:kms:us-east-1:1234567:key/configured",
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "sse-kms", d.Get("encryption_type"))
+	assert.Equal(t, "arn:aws:kms:us-east-1:1234567:key/other", d.Get("kms_key"))
+	assert.Equal(t, 1, recorder.Executed("for mount in dbutils.fs.mounts()]"))
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":      "this_cluster",
+		"mount_name":      "this_mount",
+		"s3_bucket_name":  testS3BucketName,
+		"encryption_type": "sse-kms",
+		"kms_key":         "arn:aws:kms:us-east-1:1234567:key/configured",
+	}), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "arn:aws:kms:us-east-1:1234567:key/configured", diff.Attributes["kms_key"].New)
+	assert.False(t, diff.RequiresNew(), "encryption is changed by remount")
+}
+
+func TestResourceAwsS3MountRead_NoEncryptionConfigured(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
 		State: map[string]interface{}{
 			"cluster_id":     "this_cluster",
 			"mount_name":     "this_mount",
 			"s3_bucket_name": testS3BucketName,
 		},
-		Create: true,
-	}.Apply(t)
-	require.NoError(t, err, err) // TODO: global search-replace for NoError
-	assert.Equal(t, "this_mount", d.Id())
-	assert.Equal(t, testS3BucketPath, d.Get("source"))
-}
-
-func TestResourceAwsS3MountCreate_nothing_specified(t *testing.T) {
-	_, err := qa.ResourceFixture{
-		Resource: ResourceAWSS3Mount(),
-		State: map[string]interface{}{
-			"mount_name":     "this_mount",
-			"s3_bucket_name": testS3BucketName,
-		},
-		Create: true,
+		ID:   "this_mount",
+		Read: true,
 	}.Apply(t)
-	require.EqualError(t, err, "Either cluster_id or instance_profile must be specified")
+	require.NoError(t, err, err)
+	assert.Equal(t, 0, recorder.Executed("for mount in dbutils.fs.mounts()]"))
 }
 
-func TestResourceAwsS3MountCreate_invalid_arn(t *testing.T) {
-	_, err := qa.ResourceFixture{
-		Resource: ResourceAWSS3Mount(),
-		State: map[string]interface{}{
-			"mount_name":       "this_mount",
-			"s3_bucket_name":   testS3BucketName,
-			"instance_profile": "this_mount",
+func TestResourceAwsS3MountRead_Import(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: compute.SparkVersionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: compute.NodeTypeList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{
+						{
+							ClusterID:   "mounter",
+							ClusterName: "terraform-mount",
+							State:       compute.ClusterStateRunning,
+						},
+					},
+				},
+			},
 		},
-		Create: true,
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		ID:              "this_mount",
+		Read:            true,
+		New:             true,
 	}.Apply(t)
-	require.EqualError(t, err, "arn: invalid prefix")
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "this_mount", d.Get("mount_name"))
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, testS3BucketName, d.Get("s3_bucket_name"))
+	assert.Equal(t, 1, recorder.Executed(`mount.mountPoint == "/mnt/this_mount"`))
 }
 
-func TestResourceAwsS3MountCreate_Error(t *testing.T) {
+func TestResourceAwsS3MountRead_ImportWithPrefix(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath+"/some/prefix")},
+	}
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
-				Response: compute.ClusterInfo{
-					State: compute.ClusterStateRunning,
-					AwsAttributes: &compute.AwsAttributes{
-						InstanceProfileArn: "abc",
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: compute.SparkVersionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: compute.NodeTypeList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{
+						{
+							ClusterID:   "mounter",
+							ClusterName: "terraform-mount",
+							State:       compute.ClusterStateRunning,
+						},
 					},
 				},
 			},
 		},
-		Resource: ResourceAWSS3Mount(),
-		CommandMock: func(commandStr string) (string, error) {
-			return "", errors.New("Some error")
-		},
-		State: map[string]interface{}{
-			"cluster_id":     "this_cluster",
-			"mount_name":     "this_mount",
-			"s3_bucket_name": testS3BucketName,
-		},
-		Create: true,
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		ID:              "this_mount",
+		Read:            true,
+		New:             true,
 	}.Apply(t)
-	require.EqualError(t, err, "Some error")
+	require.NoError(t, err, err)
 	assert.Equal(t, "this_mount", d.Id())
-	assert.Equal(t, "", d.Get("source"))
+	assert.Equal(t, "this_mount", d.Get("mount_name"))
+	assert.Equal(t, testS3BucketPath+"/some/prefix", d.Get("source"))
+	assert.Equal(t, testS3BucketName, d.Get("s3_bucket_name"))
+	assert.Equal(t, 1, recorder.Executed(`mount.mountPoint == "/mnt/this_mount"`))
 }
 
-func TestResourceAwsS3MountRead(t *testing.T) {
+func TestResourceAwsS3MountRead_OtherScheme(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
@@ -133,23 +1371,37 @@ func TestResourceAwsS3MountRead(t *testing.T) {
 		},
 		Resource: ResourceAWSS3Mount(),
 		CommandMock: func(commandStr string) (string, error) {
-			trunc := internal.TrimLeadingWhitespace(commandStr)
-			t.Logf("Received command:\n%s", trunc)
-			assert.Contains(t, trunc, "dbutils.fs.mounts()")
-			assert.Contains(t, trunc, `mount.mountPoint == "/mnt/this_mount"`)
-			return testS3BucketPath, nil
+			return testMountListing("this_mount", "s3n://"+testS3BucketName), nil
 		},
 		State: map[string]interface{}{
 			"cluster_id":     "this_cluster",
 			"mount_name":     "this_mount",
 			"s3_bucket_name": testS3BucketName,
+			"scheme":         "s3a",
 		},
 		ID:   "this_mount",
 		Read: true,
 	}.Apply(t)
 	require.NoError(t, err)
-	assert.Equal(t, "this_mount", d.Id())
-	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, "s3n", d.Get("scheme"))
+	assert.Equal(t, testS3BucketName, d.Get("s3_bucket_name"))
+}
+
+func TestParseS3Source(t *testing.T) {
+	for source, expected := range map[string][3]string{
+		"s3a://a":           {"s3a", "a", ""},
+		"s3n://b":           {"s3n", "b", ""},
+		"s3://c":            {"s3", "c", ""},
+		"wasbs://d@e/f":     {"", "wasbs://d@e/f", ""},
+		"s3a://g/with/dir":  {"s3a", "g", "with/dir"},
+		"s3a://h/":          {"s3a", "h", ""},
+		"s3a://i/with/dir/": {"s3a", "i", "with/dir"},
+	} {
+		scheme, bucket, prefix := parseS3Source(source)
+		assert.Equal(t, expected[0], scheme, source)
+		assert.Equal(t, expected[1], bucket, source)
+		assert.Equal(t, expected[2], prefix, source)
+	}
 }
 
 func TestResourceAwsS3MountRead_NotFound(t *testing.T) {
@@ -218,6 +1470,195 @@ func TestResourceAwsS3MountRead_Error(t *testing.T) {
 	assert.Equal(t, "", d.Get("source"))
 }
 
+var testS3MountWithProfileState = map[string]string{
+	"cluster_id":       "old_cluster",
+	"instance_profile": "arn:aws:iam::1234567:instance-profile/a",
+	"mount_name":       "this_mount",
+	"s3_bucket_name":   testS3BucketName,
+	"scheme":           "s3a",
+	"source":           testS3BucketPath,
+}
+
+func TestResourceAwsS3MountUpdate_InstanceProfileChange(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: compute.SparkVersionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: compute.NodeTypeList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: compute.ClusterList{
+					Clusters: []compute.ClusterInfo{
+						{
+							ClusterID:   "new_cluster",
+							ClusterName: "terraform-mount-b",
+							State:       compute.ClusterStateRunning,
+							CustomTags: map[string]string{
+								MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/b",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=new_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID: "new_cluster",
+					State:     compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/b",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		InstanceState:   testS3MountWithProfileState,
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/b"`,
+		ID:     "this_mount",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "new_cluster", d.Get("cluster_id"))
+
+	// unmount, mount with the new credentials and read the source
+	commands := recorder.Commands()
+	require.Len(t, commands, 3)
+	assert.Contains(t, commands[0], "dbutils.fs.unmount(mount_point)")
+	assert.Contains(t, commands[1], fmt.Sprintf(`safe_mount("/mnt/this_mount", "%s", {}, False)`,
+		testS3BucketPath))
+	assert.Contains(t, commands[2], `if mount.mountPoint == "/mnt/this_mount"]))`)
+}
+
+func TestResourceAwsS3MountUpdate_EncryptionChange(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Responses: []qa.CommandResponse{
+			{Result: ""},
+			{Result: testMountListing("this_mount", testS3BucketPath)},
+			{Result: testMountListing("this_mount", testS3BucketPath)},
+		},
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID: "this_cluster",
+					State:     compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		InstanceState: map[string]string{
+			"cluster_id":      "this_cluster",
+			"mount_name":      "this_mount",
+			"s3_bucket_name":  testS3BucketName,
+			"scheme":          "s3a",
+			"source":          testS3BucketPath,
+			"encryption_type": "sse-s3",
+		},
+		HCL: `
+		cluster_id = "this_cluster"
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		encryption_type = "sse-kms"
+		kms_key = "arn:aws:kms:us-east-1:1234567:key/abc"`,
+		ID:     "this_mount",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+
+	commands := recorder.Commands()
+	require.Len(t, commands, 3)
+	assert.Contains(t, commands[0], "dbutils.fs.unmount(mount_point)")
+	assert.Contains(t, commands[1], `dbutils.fs.mount(mount_source, mount_point, `+
+		`encryption_type="sse-kms:arn:aws:kms:us-east-1:1234567:key/abc", extra_configs=configs)`)
+	assert.Contains(t, commands[2], `if mount.mountPoint == "/mnt/this_mount"]))`)
+}
+
+func TestResourceAwsS3MountCreate_KmsKeyWithoutKmsEncryption(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/s3-access"
+		encryption_type = "sse-s3"
+		kms_key = "arn:aws:kms:us-east-1:1234567:key/abc"`,
+		Create: true,
+	}.ExpectError(t, "kms_key requires encryption_type to be sse-kms")
+}
+
+func TestResourceAwsS3MountUpdate_ClusterWithSameProfile(t *testing.T) {
+	sameProfile := &compute.AwsAttributes{
+		InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/a",
+	}
+	recorder := &qa.CommandRecorder{}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=new_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID:     "new_cluster",
+					State:         compute.ClusterStateRunning,
+					AwsAttributes: sameProfile,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=old_cluster",
+				Response: compute.ClusterInfo{
+					ClusterID:     "old_cluster",
+					State:         compute.ClusterStateTerminated,
+					AwsAttributes: sameProfile,
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		InstanceState: map[string]string{
+			"cluster_id":     "old_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+			"scheme":         "s3a",
+			"source":         testS3BucketPath,
+		},
+		HCL: `
+		cluster_id = "new_cluster"
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"`,
+		ID:     "this_mount",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "new_cluster", d.Get("cluster_id"))
+	assert.Len(t, recorder.Commands(), 0, "bucket is mounted with the same credentials")
+}
+
 func TestResourceAwsS3MountDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -254,20 +1695,151 @@ func TestResourceAwsS3MountDelete(t *testing.T) {
 	assert.Equal(t, "", d.Get("source"))
 }
 
+func taggedMountingClusterFixtures(state compute.ClusterState) []qa.HTTPFixture {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: compute.SparkVersionsList{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list-node-types",
+			Response: compute.NodeTypeList{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list",
+			Response: compute.ClusterList{
+				Clusters: []compute.ClusterInfo{
+					{
+						ClusterID:   "tagged",
+						ClusterName: "renamed-mounter",
+						State:       state,
+						CustomTags: map[string]string{
+							MountingClusterInstanceProfileTag: instanceProfile,
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=tagged",
+			Response: compute.ClusterInfo{
+				ClusterID: "tagged",
+				State:     state,
+			},
+		},
+	}
+}
+
+func startedTaggedClusterFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/start",
+			ExpectedRequest: compute.ClusterID{
+				ClusterID: "tagged",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=tagged",
+			Response: compute.ClusterInfo{
+				ClusterID: "tagged",
+				State:     compute.ClusterStateRunning,
+			},
+		},
+	}
+}
+
+func TestGetOrCreateMountingClusterWithInstanceProfile_ReusesByName(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: compute.SparkVersionsList{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list-node-types",
+			Response: compute.NodeTypeList{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list",
+			Response: compute.ClusterList{
+				Clusters: []compute.ClusterInfo{
+					{
+						ClusterID:   "named",
+						ClusterName: "terraform-mount-s3-access",
+						State:       compute.ClusterStateRunning,
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	clustersAPI := compute.NewClustersAPI(context.Background(), client)
+	clusterInfo, err := GetOrCreateMountingClusterWithInstanceProfile(clustersAPI,
+		"arn:aws:iam::1234567:instance-profile/s3-access")
+	require.NoError(t, err)
+	assert.Equal(t, "named", clusterInfo.ClusterID)
+}
+
+func TestGetOrCreateTaggedMountingCluster_StartsTerminated(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, append(
+		taggedMountingClusterFixtures(compute.ClusterStateTerminated),
+		startedTaggedClusterFixtures()...))
+	require.NoError(t, err)
+	defer server.Close()
+
+	clusterInfo, err := GetOrCreateTaggedMountingCluster(context.Background(),
+		client, "arn:aws:iam::1234567:instance-profile/s3-access")
+	require.NoError(t, err)
+	assert.Equal(t, "tagged", clusterInfo.ClusterID)
+	assert.Equal(t, compute.ClusterStateRunning, string(clusterInfo.State))
+}
+
+func TestGetOrCreateTaggedMountingCluster_StartsTerminating(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, append(append(
+		taggedMountingClusterFixtures(compute.ClusterStateTerminating),
+		qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=tagged",
+			Response: compute.ClusterInfo{
+				ClusterID: "tagged",
+				State:     compute.ClusterStateTerminated,
+			},
+		}), startedTaggedClusterFixtures()...))
+	require.NoError(t, err)
+	defer server.Close()
+
+	clusterInfo, err := GetOrCreateTaggedMountingCluster(context.Background(),
+		client, "arn:aws:iam::1234567:instance-profile/s3-access")
+	require.NoError(t, err)
+	assert.Equal(t, "tagged", clusterInfo.ClusterID)
+	assert.Equal(t, compute.ClusterStateRunning, string(clusterInfo.State))
+}
+
 func TestAwsAccS3Mount(t *testing.T) {
 	client := common.NewClientFromEnvironment()
 	instanceProfile := qa.GetEnvOrSkipTest(t, "TEST_EC2_INSTANCE_PROFILE")
 	ctx := context.WithValue(context.Background(), common.Current, t.Name())
 	instanceProfilesAPI := identity.NewInstanceProfilesAPI(ctx, client)
 	instanceProfilesAPI.Synchronized(instanceProfile, func() bool {
-		if err := instanceProfilesAPI.Create(instanceProfile); err != nil {
+		if err := instanceProfilesAPI.Create(instanceProfile, false); err != nil {
 			return false
 		}
 		bucket := qa.GetEnvOrSkipTest(t, "TEST_S3_BUCKET")
 		client := compute.CommonEnvironmentClientWithRealCommandExecutor()
 		clustersAPI := compute.NewClustersAPI(ctx, client)
-		clusterInfo, err := GetOrCreateMountingClusterWithInstanceProfile(
-			clustersAPI, instanceProfile)
+		clusterInfo, err := GetOrCreateTaggedMountingCluster(
+			ctx, client, instanceProfile)
 		require.NoError(t, err)
 		defer func() {
 			err = clustersAPI.PermanentDelete(clusterInfo.ClusterID)
@@ -285,3 +1857,171 @@ func TestAwsAccS3Mount(t *testing.T) {
 		return true
 	})
 }
+
+func TestResourceAwsS3MountCreate_OnAzure(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		Azure:    true,
+		HCL: `
+		instance_profile = "arn:aws:iam::1234567:instance-profile/a"
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"`,
+		Create: true,
+	}.ExpectError(t, "databricks_aws_s3_mount cannot be used on an Azure workspace")
+}
+
+func TestResourceAwsS3MountDiff_ClusterOnOtherCloud(t *testing.T) {
+	// access keys of the cluster could be used in any cloud
+	azure := &common.DatabricksClient{Host: "https://adb-123.4.azuredatabricks.net"}
+	_, err := ResourceAWSS3Mount().Diff(context.Background(), nil,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+		}), azure)
+	assert.NoError(t, err)
+}
+
+func TestResourceAwsS3MountRead_SkipReadVerification(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceAWSS3Mount(),
+		CommandMock: func(commandStr string) (string, error) {
+			assert.Fail(t, "No commands should be executed without read verification")
+			return "", nil
+		},
+		InstanceState: testS3MountWithProfileState,
+		HCL: `
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		instance_profile = "arn:aws:iam::1234567:instance-profile/a"
+		skip_read_verification = true`,
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+	assert.Equal(t, "/mnt/this_mount", d.Get("mount_point"))
+	assert.Equal(t, "s3://"+testS3BucketName, d.Get("url"))
+}
+
+func TestResourceAwsS3MountRead_SkipReadVerificationWithVerify(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountListing("this_mount", testS3BucketPath)},
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+					AwsAttributes: &compute.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource:        ResourceAWSS3Mount(),
+		CommandRecorder: recorder,
+		InstanceState: map[string]string{
+			"cluster_id":     "this_cluster",
+			"mount_name":     "this_mount",
+			"s3_bucket_name": testS3BucketName,
+			"scheme":         "s3a",
+			"source":         testS3BucketPath,
+		},
+		HCL: `
+		cluster_id = "this_cluster"
+		mount_name = "this_mount"
+		s3_bucket_name = "test-s3-bucket"
+		skip_read_verification = true
+		verify = true`,
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 1, recorder.Executed(`mount.mountPoint == "/mnt/this_mount"`))
+}
+
+func TestGetOrCreateMountingCluster_AwsAttributesOption(t *testing.T) {
+	defaults := MountingClusterAwsAttributes
+	defer func() {
+		MountingClusterAwsAttributes = defaults
+	}()
+	MountingClusterAwsAttributes.Availability = compute.AwsAvailabilitySpotWithFallback
+	MountingClusterAwsAttributes.EbsVolumeType = compute.EbsVolumeTypeGeneralPurposeSsd
+	MountingClusterAwsAttributes.EbsVolumeCount = 1
+	MountingClusterAwsAttributes.EbsVolumeSize = 32
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		registeredInstanceProfiles(instanceProfile),
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list",
+			Response:     compute.ClusterList{},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: compute.Cluster{
+				NumWorkers:             1,
+				ClusterName:            "terraform-mount-s3-access",
+				SparkVersion:           "7.3.x-scala2.12",
+				NodeTypeID:             "m5.large",
+				AutoterminationMinutes: 10,
+				AwsAttributes: &compute.AwsAttributes{
+					Availability:       "SPOT_WITH_FALLBACK",
+					ZoneID:             "auto",
+					InstanceProfileArn: instanceProfile,
+					EbsVolumeType:      "GENERAL_PURPOSE_SSD",
+					EbsVolumeCount:     1,
+					EbsVolumeSize:      32,
+				},
+				CustomTags: map[string]string{
+					MountingClusterInstanceProfileTag: instanceProfile,
+				},
+			},
+			Response: compute.ClusterID{
+				ClusterID: "bcd",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: compute.ClusterInfo{
+				ClusterID: "bcd",
+				State:     compute.ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clusterInfo, err := getOrCreateMountingCluster(ctx, client,
+			instanceProfile, &MountingCluster{
+				SparkVersion: "7.3.x-scala2.12",
+				NodeTypeID:   "m5.large",
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "bcd", clusterInfo.ClusterID)
+	})
+}
+
+func TestMountingClusterAwsAttributes(t *testing.T) {
+	instanceProfile := "arn:aws:iam::1234567:instance-profile/s3-access"
+	assert.Equal(t, &compute.AwsAttributes{
+		Availability:       "SPOT",
+		ZoneID:             "auto",
+		InstanceProfileArn: instanceProfile,
+	}, mountingClusterAwsAttributes(nil, "", instanceProfile))
+	assert.Equal(t, &compute.AwsAttributes{
+		Availability:       "ON_DEMAND",
+		ZoneID:             "auto",
+		InstanceProfileArn: instanceProfile,
+	}, mountingClusterAwsAttributes(&compute.AwsAttributes{
+		Availability: "ON_DEMAND",
+	}, "", instanceProfile))
+	assert.Equal(t, &compute.AwsAttributes{
+		InstanceProfileArn: instanceProfile,
+	}, mountingClusterAwsAttributes(nil, "pool", instanceProfile))
+}
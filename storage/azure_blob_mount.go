@@ -24,14 +24,14 @@ func (m AzureBlobMount) Source() string {
 }
 
 // Config ...
-func (m AzureBlobMount) Config() map[string]string {
+func (m AzureBlobMount) Config() MountConfig {
 	var confKey string
 	if m.AuthType == "SAS" {
 		confKey = fmt.Sprintf("fs.azure.sas.%s.%s.blob.core.windows.net", m.ContainerName, m.StorageAccountName)
 	} else {
 		confKey = fmt.Sprintf("fs.azure.account.key.%s.blob.core.windows.net", m.StorageAccountName)
 	}
-	return map[string]string{
+	return MountConfig{
 		confKey: fmt.Sprintf("{secrets/%s/%s}", m.SecretScope, m.SecretKey),
 	}
 }
@@ -50,9 +50,10 @@ func ResourceAzureBlobMount() *schema.Resource {
 		},
 		"mount_name": {
 			// TODO: have it by default as storage_resource_name
-			Type:     schema.TypeString,
-			Required: true,
-			ForceNew: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateMountName,
 		},
 		"container_name": {
 			Type:     schema.TypeString,
@@ -75,18 +76,15 @@ func ResourceAzureBlobMount() *schema.Resource {
 			Type:         schema.TypeString,
 			Required:     true,
 			ValidateFunc: validation.StringInSlice([]string{"SAS", "ACCESS_KEY"}, false),
-			ForceNew:     true,
 		},
 		"token_secret_scope": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"token_secret_key": {
 			Type:      schema.TypeString,
 			Required:  true,
 			Sensitive: true,
-			ForceNew:  true,
 		},
 	})
 }
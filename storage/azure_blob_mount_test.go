@@ -1,14 +1,18 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 
+	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/compute"
 	"github.com/databrickslabs/terraform-provider-databricks/internal"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,7 +45,7 @@ func TestResourceAzureBlobMountCreate(t *testing.T) {
 				assert.Contains(t, trunc, `"fs.azure.account.key.f.blob.core.windows.net":dbutils.secrets.get("h", "g")`)
 			}
 			assert.Contains(t, trunc, "/mnt/e")
-			return "wasbs://c@f.blob.core.windows.net/d", nil
+			return testMountListing("e", "wasbs://c@f.blob.core.windows.net/d"), nil
 		},
 		State: map[string]interface{}{
 			"auth_type":            "ACCESS_KEY",
@@ -58,6 +62,8 @@ func TestResourceAzureBlobMountCreate(t *testing.T) {
 	require.NoError(t, err, err) // TODO: global search-replace for NoError
 	assert.Equal(t, "e", d.Id())
 	assert.Equal(t, "wasbs://c@f.blob.core.windows.net/d", d.Get("source"))
+	assert.Equal(t, "/mnt/e", d.Get("mount_point"))
+	assert.Equal(t, "wasbs://c@f.blob.core.windows.net/d", d.Get("url"))
 }
 
 func TestResourceAzureBlobMountCreate_Error(t *testing.T) {
@@ -92,6 +98,37 @@ func TestResourceAzureBlobMountCreate_Error(t *testing.T) {
 	assert.Equal(t, "", d.Get("source"))
 }
 
+func TestResourceAzureBlobMountCreate_ErrorIsRedacted(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=b",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceAzureBlobMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return "", errors.New("Exception: Invalid configuration " +
+				"{'fs.azure.account.key.f.blob.core.windows.net': 'c3VwZXJzZWNyZXQ='}")
+		},
+		State: map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		},
+		Create: true,
+	}.ExpectError(t, "Invalid configuration "+
+		"{'fs.azure.account.key.f.blob.core.windows.net': '***'}")
+}
+
 func TestResourceAzureBlobMountRead(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -109,7 +146,7 @@ func TestResourceAzureBlobMountRead(t *testing.T) {
 			t.Logf("Received command:\n%s", trunc)
 			assert.Contains(t, trunc, "dbutils.fs.mounts()")
 			assert.Contains(t, trunc, `mount.mountPoint == "/mnt/e"`)
-			return "wasbs://c@f.blob.core.windows.net/d", nil
+			return testMountListing("e", "wasbs://c@f.blob.core.windows.net/d"), nil
 		},
 		State: map[string]interface{}{
 			"auth_type":            "ACCESS_KEY",
@@ -129,6 +166,57 @@ func TestResourceAzureBlobMountRead(t *testing.T) {
 	assert.Equal(t, "wasbs://c@f.blob.core.windows.net/d", d.Get("source"))
 }
 
+func testAzureBlobMountVerify(t *testing.T, lsErr error) *schema.ResourceData {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=b",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceAzureBlobMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			if strings.HasPrefix(trunc, `dbutils.fs.ls("/mnt/e")`) {
+				return "success", lsErr
+			}
+			return testMountListing("e", "wasbs://c@f.blob.core.windows.net/d"), nil
+		},
+		State: map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+			"verify":               true,
+		},
+		ID:   "e",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "e", d.Id())
+	assert.Equal(t, "wasbs://c@f.blob.core.windows.net/d", d.Get("source"))
+	return d
+}
+
+func TestResourceAzureBlobMountRead_Verified(t *testing.T) {
+	d := testAzureBlobMountVerify(t, nil)
+	assert.Equal(t, true, d.Get("healthy"))
+}
+
+func TestResourceAzureBlobMountRead_VerifyAccessDenied(t *testing.T) {
+	d := testAzureBlobMountVerify(t, errors.New("shaded.databricks.org.apache.hadoop.fs.azure."+
+		"AzureException: com.microsoft.azure.storage.StorageException: "+
+		"This request is not authorized to perform this operation."))
+	assert.Equal(t, false, d.Get("healthy"))
+}
+
 func TestResourceAzureBlobMountRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -162,6 +250,86 @@ func TestResourceAzureBlobMountRead_NotFound(t *testing.T) {
 	}.ApplyNoError(t)
 }
 
+func TestResourceAzureBlobMountRead_ClusterGone(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=b",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Cluster b does not exist",
+				},
+			},
+		},
+		Resource: ResourceAzureBlobMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return "", errors.New("Mount not found")
+		},
+		State: map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		},
+		ID:   "e",
+		Read: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Mounting cluster b is unavailable: Cluster b does not exist")
+	assert.Equal(t, "e", d.Id(), "mount must stay in state")
+}
+
+func TestResourceAzureBlobMountRead_ClusterCannotStart(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=b",
+				ReuseRequest: true,
+				Response: compute.ClusterInfo{
+					ClusterID: "b",
+					State:     compute.ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/start",
+				ExpectedRequest: compute.ClusterID{
+					ClusterID: "b",
+				},
+				Status: 400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_STATE",
+					Message:   "Cluster b cannot be started",
+				},
+			},
+		},
+		Resource: ResourceAzureBlobMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			return "", errors.New("Mount not found")
+		},
+		State: map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		},
+		ID:   "e",
+		Read: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Mounting cluster b cannot be started: Cluster b cannot be started")
+	assert.Equal(t, "e", d.Id(), "mount must stay in state")
+}
+
 func TestResourceAzureBlobMountRead_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -248,3 +416,81 @@ func TestAzureAccBlobMount(t *testing.T) {
 		})
 	}, client, mp.name, accountKey)
 }
+
+func TestResourceAzureBlobMountCreate_MountsDisabledInWorkspaceConf(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace-conf?keys=enableDbfsFileBrowser",
+				Response: map[string]string{
+					"enableDbfsFileBrowser": "false",
+				},
+			},
+		},
+		Resource: ResourceAzureBlobMount(),
+		State: map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"check_workspace_conf": "enableDbfsFileBrowser",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		},
+		Create: true,
+	}.ExpectError(t, "DBFS mounts are disabled in this workspace, "+
+		"as enableDbfsFileBrowser is false in workspace configuration")
+}
+
+func TestResourceAzureBlobMountRead_SkipReadVerification(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceAzureBlobMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			assert.Fail(t, "No commands should be executed without read verification")
+			return "", nil
+		},
+		InstanceState: map[string]string{
+			"auth_type":            "ACCESS_KEY",
+			"cluster_id":           "b",
+			"container_name":       "c",
+			"directory":            "/d",
+			"mount_name":           "e",
+			"source":               "wasbs://c@f.blob.core.windows.net/d",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		},
+		HCL: `
+		auth_type = "ACCESS_KEY"
+		cluster_id = "b"
+		container_name = "c"
+		directory = "/d"
+		mount_name = "e"
+		storage_account_name = "f"
+		token_secret_key = "g"
+		token_secret_scope = "h"
+		skip_read_verification = true`,
+		ID:   "e",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "wasbs://c@f.blob.core.windows.net/d", d.Get("source"))
+	assert.Equal(t, "/mnt/e", d.Get("mount_point"))
+}
+
+func TestResourceAzureBlobMountDiff_OnAWS(t *testing.T) {
+	aws := &common.DatabricksClient{Host: "https://abc.cloud.databricks.com"}
+	_, err := ResourceAzureBlobMount().Diff(context.Background(), nil,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"auth_type":            "ACCESS_KEY",
+			"container_name":       "c",
+			"mount_name":           "e",
+			"storage_account_name": "f",
+			"token_secret_key":     "g",
+			"token_secret_scope":   "h",
+		}), aws)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MountInfo describes a mount, as returned by dbutils.fs.mounts()
+type MountInfo struct {
+	MountName      string `json:"mount_name"`
+	MountPoint     string `json:"mount_point"`
+	Source         string `json:"source"`
+	EncryptionType string `json:"encryption_type,omitempty"`
+}
+
+// mountsJSON is python code, that exits the command with JSON list of mounts matching
+// the condition on `mount` variable, or of all mounts for empty condition
+func mountsJSON(condition string) string {
+	if condition != "" {
+		condition = " if " + condition
+	}
+	return fmt.Sprintf(`
+		import json
+		dbutils.fs.refreshMounts()
+		dbutils.notebook.exit(json.dumps([{
+			"mount_point": mount.mountPoint,
+			"source": mount.source,
+			"encryption_type": mount.encryptionType,
+		} for mount in dbutils.fs.mounts()%s]))
+	`, condition)
+}
+
+// parseMounts returns typed mounts from results of the command, that ends with mountsJSON,
+// sorted by mount point
+func parseMounts(result common.CommandResults) (mounts []MountInfo, err error) {
+	if result.Failed() {
+		return nil, result.Err()
+	}
+	if err = json.Unmarshal([]byte(result.Text()), &mounts); err != nil {
+		return nil, fmt.Errorf("Cannot parse mounts: %v", err)
+	}
+	for i := range mounts {
+		mounts[i].MountName = strings.TrimPrefix(mounts[i].MountPoint, "/mnt/")
+	}
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].MountPoint < mounts[j].MountPoint
+	})
+	return
+}
+
+// ListMounts returns all mounts visible from the given cluster, sorted by mount point
+func ListMounts(executor common.CommandExecutor, clusterID string) ([]MountInfo, error) {
+	return parseMounts(executor.Execute(clusterID, "python", mountsJSON("")))
+}
+
+// DataSourceMounts lists mounts in the workspace
+func DataSourceMounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			client := m.(*common.DatabricksClient)
+			clusterID, err := getMountingClusterID(ctx, client, d.Get("cluster_id").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			mounts, err := ListMounts(client.CommandExecutor(ctx), clusterID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			includeAll := d.Get("include_all").(bool)
+			mountList := []map[string]interface{}{}
+			for _, mount := range mounts {
+				if !includeAll && !strings.HasPrefix(mount.MountPoint, "/mnt/") {
+					continue
+				}
+				mountList = append(mountList, map[string]interface{}{
+					"mount_name":      mount.MountName,
+					"mount_point":     mount.MountPoint,
+					"source":          mount.Source,
+					"encryption_type": mount.EncryptionType,
+				})
+			}
+			d.SetId(clusterID)
+			if err = d.Set("mounts", mountList); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"include_all": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"mounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mount_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mount_point": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"encryption_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
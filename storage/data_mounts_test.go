@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMountsJSON = `[
+	{"mount_point": "/mnt/b", "source": "s3a://b", "encryption_type": "sse-s3"},
+	{"mount_point": "/databricks-datasets", "source": "databricks-datasets", "encryption_type": ""},
+	{"mount_point": "/mnt/a", "source": "abfss://a@b.dfs.core.windows.net/", "encryption_type": ""}
+]`
+
+func testMountsFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: compute.ClusterInfo{
+				ClusterID: "abc",
+				State:     compute.ClusterStateRunning,
+			},
+		},
+	}
+}
+
+func TestDataSourceMounts(t *testing.T) {
+	recorder := &qa.CommandRecorder{
+		Default: qa.CommandResponse{Result: testMountsJSON},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures:        testMountsFixtures(),
+		CommandRecorder: recorder,
+		Read:            true,
+		NonWritable:     true,
+		Resource:        DataSourceMounts(),
+		ID:              ".",
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 1, recorder.Executed("for mount in dbutils.fs.mounts()]))"))
+	assert.Equal(t, 2, d.Get("mounts.#"))
+	assert.Equal(t, "a", d.Get("mounts.0.mount_name"))
+	assert.Equal(t, "abfss://a@b.dfs.core.windows.net/", d.Get("mounts.0.source"))
+	assert.Equal(t, "b", d.Get("mounts.1.mount_name"))
+	assert.Equal(t, "/mnt/b", d.Get("mounts.1.mount_point"))
+	assert.Equal(t, "sse-s3", d.Get("mounts.1.encryption_type"))
+}
+
+func TestDataSourceMounts_IncludeAll(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: testMountsFixtures(),
+		CommandRecorder: &qa.CommandRecorder{
+			Default: qa.CommandResponse{Result: testMountsJSON},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceMounts(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"cluster_id":  "abc",
+			"include_all": true,
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("mounts.#"))
+	assert.Equal(t, "/databricks-datasets", d.Get("mounts.0.mount_point"))
+	assert.Equal(t, "/databricks-datasets", d.Get("mounts.0.mount_name"))
+}
+
+func TestDataSourceMounts_InvalidResult(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: testMountsFixtures(),
+		CommandRecorder: &qa.CommandRecorder{
+			Default: qa.CommandResponse{Result: "Notebook exited: nope"},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceMounts(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot parse mounts")
+}
+
+func TestListMounts_UnknownResultType(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandResultsMock(func(commandStr string) common.CommandResults {
+		return common.CommandResults{
+			ResultType: "table",
+			Data:       []interface{}{},
+		}
+	})
+	_, err = ListMounts(c.CommandExecutor(context.Background()), "abc")
+	assert.EqualError(t, err, "Unknown result type table: []")
+}
+
+func TestListMounts(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		return `[{"mount_point": "/mnt/b", "source": "s3a://a/mnt/b", "encryption_type": "sse-kms"},
+			{"mount_point": "/mnt/a", "source": "s3a://it's \\escaped\u00e9", "encryption_type": ""}]`, nil
+	})
+	mounts, err := ListMounts(c.CommandExecutor(context.Background()), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []MountInfo{
+		{MountName: "a", MountPoint: "/mnt/a", Source: "s3a://it's \\escaped\u00e9"},
+		{MountName: "b", MountPoint: "/mnt/b", Source: "s3a://a/mnt/b", EncryptionType: "sse-kms"},
+	}, mounts)
+}
+
+func TestListMounts_Empty(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		return "[]", nil
+	})
+	mounts, err := ListMounts(c.CommandExecutor(context.Background()), "abc")
+	require.NoError(t, err)
+	assert.Len(t, mounts, 0)
+}
@@ -20,6 +20,9 @@ type FileInfo struct {
 	FileSize int64  `json:"file_size,omitempty"`
 }
 
+// dbfsBlockSize is the maximum amount of data per add-block and read call
+const dbfsBlockSize = 1e6
+
 // CreateHandle contains the payload to create a handle which is a connection for uploading blocks of file data
 type CreateHandle struct {
 	Path      string `json:"path,omitempty"`
@@ -68,7 +71,7 @@ func (a DbfsAPI) Create(path string, byteArr []byte, overwrite bool) (err error)
 	}()
 	buffer := bytes.NewBuffer(byteArr)
 	for {
-		byteChunk := buffer.Next(1e6)
+		byteChunk := buffer.Next(dbfsBlockSize)
 		if len(byteChunk) == 0 {
 			break
 		}
@@ -161,7 +164,7 @@ type dbfsRequest struct {
 func (a DbfsAPI) Read(path string) (content []byte, err error) {
 	fetchLoop := true
 	offSet := int64(0)
-	length := int64(1e6)
+	length := int64(dbfsBlockSize)
 	for fetchLoop {
 		bytesRead, bytes, err := a.read(path, offSet, length)
 		if err != nil {
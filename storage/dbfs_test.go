@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/base64"
 	"os"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func GenString(times int) []byte {
@@ -20,6 +23,48 @@ func GenString(times int) []byte {
 	return buf.Bytes()
 }
 
+func TestCreateFile_MultipleBlocks(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 2*dbfsBlockSize+10)
+	addBlock := func(size int) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[:size]),
+				Handle: 123,
+			},
+		}
+	}
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: CreateHandle{
+				Path:      "/large",
+				Overwrite: true,
+			},
+			Response: Handle{
+				Handle: 123,
+			},
+		},
+		addBlock(dbfsBlockSize),
+		addBlock(dbfsBlockSize),
+		addBlock(10),
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{
+				Handle: 123,
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	err = NewDbfsAPI(context.Background(), client).Create("/large", content, true)
+	require.NoError(t, err)
+}
+
 func TestAccCreateFile(t *testing.T) {
 	if _, ok := os.LookupEnv("CLOUD_ENV"); !ok {
 		t.Skip("Acceptance tests skipped unless env 'CLOUD_ENV' is set")
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GSMount describes the object for a Google Cloud Storage mount, that either uses
+// service account of the mounting cluster or a service account key from secret scope
+type GSMount struct {
+	BucketName     string `json:"bucket_name"`
+	ServiceAccount string `json:"service_account"`
+	KeySecretScope string `json:"key_secret_scope,omitempty"`
+	KeySecretKey   string `json:"key_secret_key,omitempty"`
+	PrivateKeyID   string `json:"private_key_id,omitempty"`
+}
+
+// Source ...
+func (m GSMount) Source() string {
+	return fmt.Sprintf("gs://%s", m.BucketName)
+}
+
+// Config ...
+func (m GSMount) Config() MountConfig {
+	if m.isKeyless() {
+		// credentials come from the service account of the mounting cluster
+		return MountConfig{}
+	}
+	return MountConfig{
+		"google.cloud.auth.service.account.enable":  "true",
+		"fs.gs.auth.service.account.email":          m.ServiceAccount,
+		"fs.gs.auth.service.account.private.key.id": m.PrivateKeyID,
+		"fs.gs.auth.service.account.private.key":    fmt.Sprintf("{secrets/%s/%s}", m.KeySecretScope, m.KeySecretKey),
+	}
+}
+
+func (m GSMount) isKeyless() bool {
+	return m.KeySecretKey == ""
+}
+
+// ResourceGoogleCloudStorageMount creates the resource
+func ResourceGoogleCloudStorageMount() *schema.Resource {
+	keyFields := []string{"key_secret_scope", "key_secret_key", "private_key_id"}
+	tpl := GSMount{}
+	r := commonMountResource(tpl, map[string]*schema.Schema{
+		"cluster_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+		"source": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"mount_name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateMountName,
+		},
+		"bucket_name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"service_account": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"key_secret_scope": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: keyFields,
+		},
+		"key_secret_key": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ForceNew:     true,
+			RequiredWith: keyFields,
+		},
+		"private_key_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: keyFields,
+		},
+	})
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := preprocessGsMount(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+		return create(ctx, d, m)
+	}
+	return requireMountCloud("databricks_gcs_mount", common.CloudGCP, r,
+		func(d *schema.ResourceDiff) bool {
+			return d.Get("key_secret_key").(string) == ""
+		})
+}
+
+// preprocessGsMount creates mounting cluster with the service account for
+// keyless mounts, if no cluster is given
+func preprocessGsMount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("cluster_id").(string) != "" || d.Get("key_secret_key").(string) != "" {
+		return nil
+	}
+	serviceAccount := d.Get("service_account").(string)
+	clustersAPI := compute.NewClustersAPI(ctx, m)
+	clusterName := fmt.Sprintf("terraform-mount-gcs-%s",
+		strings.Split(serviceAccount, "@")[0])
+	cluster := singleNodeMountingCluster(clustersAPI, clusterName,
+		m.(*common.DatabricksClient).DefaultClusterTags)
+	cluster.GcpAttributes = &compute.GcpAttributes{
+		GoogleServiceAccount: serviceAccount,
+	}
+	info, err := clustersAPI.GetOrCreateRunningCluster(clusterName, cluster)
+	if err != nil {
+		return err
+	}
+	return d.Set("cluster_id", info.ClusterID)
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/databrickslabs/terraform-provider-databricks/internal"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceGcsMountCreate_Keyless(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: compute.SparkVersionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: compute.NodeTypeList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: compute.ClusterList{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: compute.Cluster{
+					ClusterName:            "terraform-mount-gcs-reader",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 10,
+					SparkConf: map[string]string{
+						"spark.master":                     "local[*]",
+						"spark.databricks.cluster.profile": "singleNode",
+					},
+					CustomTags: map[string]string{
+						"ResourceClass": "SingleNode",
+					},
+					GcpAttributes: &compute.GcpAttributes{
+						GoogleServiceAccount: "reader@project.iam.gserviceaccount.com",
+					},
+				},
+				Response: compute.ClusterID{
+					ClusterID: "bcd",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=bcd",
+				Response: compute.ClusterInfo{
+					ClusterID: "bcd",
+					State:     compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceGoogleCloudStorageMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			if strings.HasPrefix(trunc, "def safe_mount") {
+				assert.Contains(t, trunc, `safe_mount("/mnt/this_mount", "gs://data", {}, False)`)
+			}
+			return testMountListing("this_mount", "gs://data"), nil
+		},
+		HCL: `
+		mount_name = "this_mount"
+		bucket_name = "data"
+		service_account = "reader@project.iam.gserviceaccount.com"`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "bcd", d.Get("cluster_id"))
+	assert.Equal(t, "gs://data", d.Get("source"))
+	assert.Equal(t, "/mnt/this_mount", d.Get("mount_point"))
+	assert.Equal(t, "gs://data", d.Get("url"))
+}
+
+func TestResourceGcsMountCreate_KeyFromSecretScope(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: compute.ClusterInfo{
+					State: compute.ClusterStateRunning,
+				},
+			},
+		},
+		Resource: ResourceGoogleCloudStorageMount(),
+		CommandMock: func(commandStr string) (string, error) {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			if strings.HasPrefix(trunc, "def safe_mount") {
+				assert.Contains(t, trunc, `"fs.gs.auth.service.account.email":"reader@project.iam.gserviceaccount.com"`)
+				assert.Contains(t, trunc, `"fs.gs.auth.service.account.private.key":dbutils.secrets.get("gcp", "key")`)
+				assert.Contains(t, trunc, `"fs.gs.auth.service.account.private.key.id":"abc123"`)
+				assert.Contains(t, trunc, `"google.cloud.auth.service.account.enable":"true"`)
+			}
+			return testMountListing("this_mount", "gs://data"), nil
+		},
+		HCL: `
+		cluster_id = "this_cluster"
+		mount_name = "this_mount"
+		bucket_name = "data"
+		service_account = "reader@project.iam.gserviceaccount.com"
+		key_secret_scope = "gcp"
+		key_secret_key = "key"
+		private_key_id = "abc123"`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "gs://data", d.Get("source"))
+}
+
+func TestResourceGcsMountCreate_IncompleteKey(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGoogleCloudStorageMount(),
+		HCL: `
+		cluster_id = "this_cluster"
+		mount_name = "this_mount"
+		bucket_name = "data"
+		service_account = "reader@project.iam.gserviceaccount.com"
+		key_secret_key = "key"`,
+		Create: true,
+	}.ExpectError(t, "Invalid config supplied. [key_secret_key] RequiredWith")
+}
+
+func TestResourceGcsMountCreate_OnAzure(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceGoogleCloudStorageMount(),
+		Azure:    true,
+		HCL: `
+		mount_name = "this_mount"
+		bucket_name = "data"
+		service_account = "reader@project.iam.gserviceaccount.com"`,
+		Create: true,
+	}.ExpectError(t, "databricks_gcs_mount cannot be used on an Azure workspace")
+}
+
+func TestResourceGcsMountDiff_KeyOnAzure(t *testing.T) {
+	azure := &common.DatabricksClient{Host: "https://adb-123.4.azuredatabricks.net"}
+	_, err := ResourceGoogleCloudStorageMount().Diff(context.Background(), nil,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"mount_name":       "this_mount",
+			"bucket_name":      "data",
+			"service_account":  "reader@project.iam.gserviceaccount.com",
+			"key_secret_scope": "a",
+			"key_secret_key":   "b",
+			"private_key_id":   "c",
+		}), azure)
+	assert.NoError(t, err)
+}
@@ -2,24 +2,95 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/databrickslabs/terraform-provider-databricks/access"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/databrickslabs/terraform-provider-databricks/workspace"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Mount exposes generic url & extra config map options
 type Mount interface {
 	Source() string
-	Config() map[string]string
+	Config() MountConfig
+}
+
+// encryptedMount is implemented by mounts with server-side encryption, that is
+// given to dbutils.fs.mount as encryption type, like `sse-kms:<key>`
+type encryptedMount interface {
+	Encryption() string
+}
+
+// MountConfig holds extra configuration for the mount. Values in `{secrets/scope/key}`
+// format are resolved with `dbutils.secrets.get` on the cluster, so that secrets never
+// appear in the generated command.
+type MountConfig map[string]string
+
+// String renders configuration as properly escaped python dictionary literal
+func (mc MountConfig) String() string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, fmt.Sprintf("%s:%s", pythonString(k), pythonValue(mc[k])))
+	}
+	return "{" + strings.Join(items, ",") + "}"
+}
+
+// secretReferences returns scope/key pairs of all `{secrets/scope/key}` values,
+// ordered by configuration key
+func (mc MountConfig) secretReferences() (refs [][2]string) {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if scope, key, ok := common.MountSecretReference.Parse(mc[k]); ok {
+			refs = append(refs, [2]string{scope, key})
+		}
+	}
+	return
+}
+
+// pythonValue renders either a secret lookup or escaped string literal
+func pythonValue(v string) string {
+	if scope, key, ok := common.MountSecretReference.Parse(v); ok {
+		return fmt.Sprintf("dbutils.secrets.get(%s, %s)",
+			pythonString(scope), pythonString(key))
+	}
+	return pythonString(v)
+}
+
+// mountNameRE allows nested mount names, like `a/b`, but nothing, that might
+// need escaping in generated python or in mount paths
+var mountNameRE = regexp.MustCompile(`^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$`)
+
+// validateMountName rejects mount names outside of safe character set
+var validateMountName = validation.StringMatch(mountNameRE,
+	"must contain only alphanumerics, dashes, underscores and slashes between nested names")
+
+// pythonString returns double-quoted string literal, where quotes, backslashes
+// and non-printable characters are escaped compatible with python syntax
+func pythonString(v string) string {
+	return strconv.Quote(v)
 }
 
 // MountPoint is something actionable
@@ -29,46 +100,89 @@ type MountPoint struct {
 	name      string
 }
 
-// Source returns mountpoint source
+// mountPoint returns python string literal of the mount path, so that the name
+// could never break out of the quotes in generated commands
+func (mp MountPoint) mountPoint() string {
+	return pythonString("/mnt/" + mp.name)
+}
+
+// Source returns mountpoint source or `Mount not found` error
 func (mp MountPoint) Source() (string, error) {
-	return mp.exec.Execute(mp.clusterID, "python", fmt.Sprintf(`
-		dbutils.fs.refreshMounts()
-		for mount in dbutils.fs.mounts():
-			if mount.mountPoint == "/mnt/%s":
-				dbutils.notebook.exit(mount.source)
-		raise Exception("Mount not found")
-	`, mp.name))
+	return mp.sourceFrom(mp.exec.Execute(mp.clusterID, "python",
+		mountsJSON(fmt.Sprintf("mount.mountPoint == %s", mp.mountPoint()))))
+}
+
+// sourceFrom returns source of this mount point from typed mounts in command results,
+// so that mount point is matched exactly instead of by substring of the output
+func (mp MountPoint) sourceFrom(result common.CommandResults) (string, error) {
+	mounts, err := parseMounts(result)
+	if err != nil {
+		return "", common.RedactError(err)
+	}
+	for _, mount := range mounts {
+		if mount.MountPoint == "/mnt/"+mp.name {
+			return mount.Source, nil
+		}
+	}
+	return "", fmt.Errorf("Mount not found")
 }
 
 // Delete removes mount from workspace
 func (mp MountPoint) Delete() error {
-	_, err := mp.exec.Execute(mp.clusterID, "python", fmt.Sprintf(`
-		mount_point = "/mnt/%s"
+	result := mp.exec.Execute(mp.clusterID, "python", fmt.Sprintf(`
+		mount_point = %s
 		dbutils.fs.unmount(mount_point)
 		dbutils.fs.refreshMounts()
 		for mount in dbutils.fs.mounts():
 			if mount.mountPoint == mount_point:
 				raise Exception("Failed to unmount")
 		dbutils.notebook.exit("success")
-	`, mp.name))
-	return err
+	`, mp.mountPoint()))
+	return common.RedactError(result.Err())
+}
+
+// Verify lists the root of the mount, so that mounts of deleted buckets or
+// with revoked permissions fail
+func (mp MountPoint) Verify() error {
+	result := mp.exec.Execute(mp.clusterID, "python", fmt.Sprintf(`
+		dbutils.fs.ls(%s)
+		dbutils.notebook.exit("success")
+	`, mp.mountPoint()))
+	return common.RedactError(result.Err())
 }
 
 // Mount mounts object store on workspace
 func (mp MountPoint) Mount(mo Mount) (source string, err error) {
-	extraConfigs, err := json.Marshal(mo.Config())
-	if err != nil {
-		return
+	return mp.safeMount(mo, false)
+}
+
+// UpdateMount changes configuration of existing mount with dbutils.fs.updateMount,
+// so that mount point stays available all the time
+func (mp MountPoint) UpdateMount(mo Mount) (source string, err error) {
+	return mp.safeMount(mo, true)
+}
+
+func (mp MountPoint) safeMount(mo Mount, update bool) (source string, err error) {
+	pythonUpdate := "False"
+	if update {
+		pythonUpdate = "True"
+	}
+	mountOptions := "extra_configs=configs"
+	if em, ok := mo.(encryptedMount); ok && em.Encryption() != "" {
+		mountOptions = fmt.Sprintf("encryption_type=%s, %s", pythonString(em.Encryption()), mountOptions)
 	}
-	b := regexp.MustCompile(`"\{secrets/([^/]+)/([^\}]+)\}"`)
-	extraConfigs = b.ReplaceAll(extraConfigs, []byte(`dbutils.secrets.get("$1", "$2")`))
 	command := fmt.Sprintf(`
-		def safe_mount(mount_point, mount_source, configs):
+		def safe_mount(mount_point, mount_source, configs, update):
+			if update:
+				dbutils.fs.updateMount(mount_source, mount_point, %[1]s)
+				dbutils.fs.refreshMounts()
+				dbutils.fs.ls(mount_point)
+				return mount_source
 			for mount in dbutils.fs.mounts():
 				if mount.mountPoint == mount_point and mount.source == mount_source:
 					return
 			try:
-				dbutils.fs.mount(mount_source, mount_point, extra_configs=configs)
+				dbutils.fs.mount(mount_source, mount_point, %[1]s)
 				dbutils.fs.refreshMounts()
 				dbutils.fs.ls(mount_point)
 				return mount_source
@@ -78,25 +192,123 @@ func (mp MountPoint) Mount(mo Mount) (source string, err error) {
 				except Exception as e2:
 					print("Failed to unmount", e2)
 				raise e
-		mount_source = safe_mount("/mnt/%s", "%v", %s)
-		dbutils.notebook.exit(mount_source)
-	`, mp.name, mo.Source(), extraConfigs)
-	source, err = mp.exec.Execute(mp.clusterID, "python", command)
-	return
+		safe_mount(%[2]s, %[3]s, %[4]s, %[5]s)
+	`, mountOptions, mp.mountPoint(), pythonString(mo.Source()), mo.Config(), pythonUpdate)
+	command += mountsJSON(fmt.Sprintf("mount.mountPoint == %s", mp.mountPoint()))
+	return mp.sourceFrom(mp.exec.Execute(mp.clusterID, "python", command))
+}
+
+// hasUpdatableFields is true, if any configurable field of the schema doesn't force new resource
+func hasUpdatableFields(s map[string]*schema.Schema) bool {
+	for _, v := range s {
+		if !v.ForceNew && (v.Optional || v.Required) {
+			return true
+		}
+	}
+	return false
+}
+
+// addMountVerificationFields adds opt-in verification of the mount during read
+func addMountVerificationFields(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["verify"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["healthy"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+	s["skip_read_verification"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["check_workspace_conf"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	return s
+}
+
+// addMountLocationFields adds paths of the mount, that could be referenced by
+// notebooks and jobs without string concatenation
+func addMountLocationFields(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["mount_point"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["url"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return s
 }
 
 func commonMountResource(tpl Mount, s map[string]*schema.Schema) *schema.Resource {
-	resource := &schema.Resource{Schema: s, SchemaVersion: 2}
+	updatable := hasUpdatableFields(s)
+	resource := &schema.Resource{
+		Schema:        addMountLocationFields(addMountVerificationFields(addMountSecretValidationField(s))),
+		SchemaVersion: 2,
+	}
 	// nolint should be a bigger context-aware refactor
 	resource.CreateContext = mountCreate(tpl, resource)
 	resource.ReadContext = mountRead(tpl, resource)
 	resource.DeleteContext = mountDelete(tpl, resource)
+	resource.Timeouts = mountTimeouts()
+	resource.Timeouts.Update = schema.DefaultTimeout(DefaultMountTimeout)
+	if updatable {
+		// only mount configuration changes, source of the mount is the same
+		resource.UpdateContext = mountUpdate(tpl, resource)
+	} else {
+		// only verify flag could change, so the mount has to be read again
+		resource.UpdateContext = schema.UpdateContextFunc(mountRead(tpl, resource))
+	}
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if err := validateSecretNames(d, s); err != nil {
+			return err
+		}
+		return validatePlannedMountSecrets(ctx, d, m, s)
+	}
 	resource.Importer = &schema.ResourceImporter{
 		StateContext: schema.ImportStatePassthroughContext,
 	}
 	return resource
 }
 
+// requireMountCloud fails the plan, when the mount relies on credentials of the mounting
+// cluster, that exist only in one cloud, like instance profiles, and is used on a workspace
+// in other cloud. Mounts with explicit credentials, like account keys, work anywhere.
+func requireMountCloud(name, cloud string, r *schema.Resource,
+	usesClusterCredentials func(d *schema.ResourceDiff) bool) *schema.Resource {
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if c, ok := m.(*common.DatabricksClient); ok && usesClusterCredentials(d) {
+			if err := c.ValidateCloud(name, cloud); err != nil {
+				return err
+			}
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(ctx, d, m)
+	}
+	return r
+}
+
+// DefaultMountTimeout is generous enough to start the mounting cluster and run the command
+const DefaultMountTimeout = 45 * time.Minute
+
+// mountTimeouts makes operations on mounts to be bound by configurable timeouts,
+// that are honored through the context of cluster and command APIs
+func mountTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(DefaultMountTimeout),
+		Read:   schema.DefaultTimeout(DefaultMountTimeout),
+		Delete: schema.DefaultTimeout(DefaultMountTimeout),
+	}
+}
+
 // NewMountPoint returns new mount point config
 func NewMountPoint(executor common.CommandExecutor, name, clusterID string) MountPoint {
 	return MountPoint{
@@ -106,50 +318,361 @@ func NewMountPoint(executor common.CommandExecutor, name, clusterID string) Moun
 	}
 }
 
+// TerminateMountingClusterAfterUse makes mount resources terminate the mounting cluster
+// as soon as mount or unmount command completes, instead of waiting for autotermination.
+// Only clusters created by mount resources are terminated, never the user clusters.
+// It's meant for programs embedding the provider and is not exposed in the configuration.
+// Cluster is kept running while other mount operations of the same process use it, but
+// operations of other processes sharing the mounting cluster are not tracked.
+var TerminateMountingClusterAfterUse = false
+
+// checkMountsEnabled returns error, if the workspace configuration key from
+// `check_workspace_conf`, that is used to turn off DBFS access, is false
+func checkMountsEnabled(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	key := d.Get("check_workspace_conf").(string)
+	if key == "" {
+		return nil
+	}
+	conf := map[string]interface{}{
+		key: "",
+	}
+	if err := workspace.NewWorkspaceConfAPI(ctx, m).Read(&conf); err != nil {
+		return err
+	}
+	if conf[key] == "false" {
+		return fmt.Errorf("DBFS mounts are disabled in this workspace, "+
+			"as %s is false in workspace configuration", key)
+	}
+	return nil
+}
+
+// secretFieldPairs returns names of `<prefix>_secret_scope` and `<prefix>_secret_key`
+// attribute pairs, ordered by the scope attribute
+func secretFieldPairs(s map[string]*schema.Schema) (pairs [][2]string) {
+	scopeFields := []string{}
+	for k := range s {
+		if strings.HasSuffix(k, "_secret_scope") {
+			scopeFields = append(scopeFields, k)
+		}
+	}
+	sort.Strings(scopeFields)
+	for _, scopeField := range scopeFields {
+		keyField := strings.TrimSuffix(scopeField, "_scope") + "_key"
+		if _, ok := s[keyField]; ok {
+			pairs = append(pairs, [2]string{scopeField, keyField})
+		}
+	}
+	return
+}
+
+// validateSecretNames checks, that every `<prefix>_secret_scope` and `<prefix>_secret_key`
+// pair of attributes forms a valid `{secrets/scope/key}` reference. It does no API calls,
+// so that plans don't need a running cluster.
+func validateSecretNames(d *schema.ResourceDiff, s map[string]*schema.Schema) error {
+	for _, pair := range secretFieldPairs(s) {
+		scopeField, keyField := pair[0], pair[1]
+		if !d.NewValueKnown(scopeField) || !d.NewValueKnown(keyField) {
+			continue
+		}
+		scope := d.Get(scopeField).(string)
+		key := d.Get(keyField).(string)
+		if scope == "" && key == "" {
+			continue
+		}
+		ref := common.MountSecretReference.Format(scope, key)
+		refScope, refKey, ok := common.MountSecretReference.Parse(ref)
+		if !ok || refScope != scope || refKey != key {
+			return fmt.Errorf("%s and %s do not form a valid secret reference: %s",
+				scopeField, keyField, ref)
+		}
+	}
+	return nil
+}
+
+// addMountSecretValidationField adds opt-in check, that every secret scope and key
+// referenced in mount configuration exists, to mounts with secret attributes.
+// It is disabled by default, as it makes extra secrets API calls.
+func addMountSecretValidationField(s map[string]*schema.Schema) map[string]*schema.Schema {
+	if len(secretFieldPairs(s)) == 0 {
+		return s
+	}
+	s["validate_secrets"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	return s
+}
+
+// validateSecretsEnabled tells if the mount has opted in for checking referenced secrets
+func validateSecretsEnabled(d interface{ Get(string) interface{} }, s map[string]*schema.Schema) bool {
+	if _, ok := s["validate_secrets"]; !ok {
+		return false
+	}
+	return d.Get("validate_secrets").(bool)
+}
+
+// validatePlannedMountSecrets returns error, if known secret scope and key attributes
+// refer to missing secret, so that it's reported already during plan
+func validatePlannedMountSecrets(ctx context.Context, d *schema.ResourceDiff,
+	m interface{}, s map[string]*schema.Schema) error {
+	if m == nil || !validateSecretsEnabled(d, s) {
+		return nil
+	}
+	refs := [][2]string{}
+	for _, pair := range secretFieldPairs(s) {
+		if !d.NewValueKnown(pair[0]) || !d.NewValueKnown(pair[1]) {
+			continue
+		}
+		scope := d.Get(pair[0]).(string)
+		key := d.Get(pair[1]).(string)
+		if scope == "" || key == "" {
+			continue
+		}
+		refs = append(refs, [2]string{scope, key})
+	}
+	return checkSecretsExist(ctx, m, refs)
+}
+
+// validateMountSecrets returns error, if mount configuration refers to missing
+// secret scope or key, before the mounting cluster is started
+func validateMountSecrets(ctx context.Context, tpl interface{},
+	d *schema.ResourceData, m interface{}, r *schema.Resource) error {
+	if !validateSecretsEnabled(d, r.Schema) {
+		return nil
+	}
+	mountConfig, err := mountFromData(tpl, d, r)
+	if err != nil {
+		return err
+	}
+	return checkSecretsExist(ctx, m, mountConfig.Config().secretReferences())
+}
+
+// checkSecretsExist returns error for the first missing secret scope or key
+func checkSecretsExist(ctx context.Context, m interface{}, refs [][2]string) error {
+	secretsAPI := access.NewSecretsAPI(ctx, m)
+	scopeKeys := map[string]map[string]bool{}
+	for _, ref := range refs {
+		scope, key := ref[0], ref[1]
+		keys, ok := scopeKeys[scope]
+		if !ok {
+			secrets, err := secretsAPI.List(scope)
+			if e, ok := err.(common.APIError); ok && e.IsMissing() {
+				return fmt.Errorf("Secret scope %s does not exist", scope)
+			}
+			if err != nil {
+				return err
+			}
+			keys = map[string]bool{}
+			for _, secret := range secrets {
+				keys[secret.Key] = true
+			}
+			scopeKeys[scope] = keys
+		}
+		if !keys[key] {
+			return fmt.Errorf("Secret %s does not exist in %s scope", key, scope)
+		}
+	}
+	return nil
+}
+
+// minimumMountRuntimes maps schemes of mount sources to the oldest Databricks Runtime,
+// that could mount them. Schemes without an entry are supported by all runtimes.
+var minimumMountRuntimes = map[string][2]int{
+	"abfss": {5, 2},
+	"gs":    {7, 3},
+}
+
+// runtimeVersionRE matches major and minor version of runtimes, like `7.3.x-scala2.12`
+var runtimeVersionRE = regexp.MustCompile(`^(\d+)\.(\d+)\.`)
+
+// validateMountRuntime returns error, if the mounting cluster runs a Databricks Runtime,
+// that is older than the minimum one for the mount source. Clusters with versions, that
+// cannot be parsed, like custom images, pass the validation.
+func validateMountRuntime(ctx context.Context, m interface{}, clusterID string, mount Mount) error {
+	scheme := strings.ToLower(strings.SplitN(mount.Source(), "://", 2)[0])
+	minimum, ok := minimumMountRuntimes[scheme]
+	if !ok {
+		return nil
+	}
+	clusterInfo, err := compute.NewClustersAPI(ctx, m).Get(clusterID)
+	if err != nil {
+		return err
+	}
+	match := runtimeVersionRE.FindStringSubmatch(clusterInfo.SparkVersion)
+	if len(match) != 3 {
+		log.Printf("[DEBUG] Cannot check runtime version %q of cluster %s for %s mount",
+			clusterInfo.SparkVersion, clusterID, scheme)
+		return nil
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	if major > minimum[0] || (major == minimum[0] && minor >= minimum[1]) {
+		return nil
+	}
+	return fmt.Errorf("%s mounts require Databricks Runtime %d.%d or above, "+
+		"but cluster %s runs %s", scheme, minimum[0], minimum[1], clusterID, clusterInfo.SparkVersion)
+}
+
+// isMountingCluster is true for clusters created by getMountingClusterID, preprocessGsMount
+// and GetOrCreateMountingClusterWithInstanceProfile. Both the name and the tags, that these
+// clusters are created with, are checked, so that user clusters named like `terraform-mount`
+// are never taken for them.
+func isMountingCluster(clusterInfo compute.ClusterInfo) bool {
+	name := clusterInfo.ClusterName
+	if name != "terraform-mount" && !strings.HasPrefix(name, "terraform-mount-") {
+		return false
+	}
+	if _, ok := clusterInfo.CustomTags[MountingClusterInstanceProfileTag]; ok {
+		return true
+	}
+	return clusterInfo.CustomTags["ResourceClass"] == "SingleNode"
+}
+
+// mountingClusterUses counts mount operations in flight per cluster within the
+// provider process. Operations are counted once the mounting cluster is resolved,
+// so other provider processes sharing the cluster are not taken into account.
+var (
+	mountingClusterUses      = map[string]int{}
+	mountingClusterUsesMutex sync.Mutex
+)
+
+// useMountingCluster registers mount operation on the cluster and returns function,
+// that releases it and tells if it was the last operation in flight. Only the first
+// call of the release has effect, so that it could be deferred for early returns.
+func useMountingCluster(clusterID string) func() bool {
+	mountingClusterUsesMutex.Lock()
+	defer mountingClusterUsesMutex.Unlock()
+	mountingClusterUses[clusterID]++
+	released := false
+	return func() bool {
+		mountingClusterUsesMutex.Lock()
+		defer mountingClusterUsesMutex.Unlock()
+		if released {
+			return false
+		}
+		released = true
+		mountingClusterUses[clusterID]--
+		if mountingClusterUses[clusterID] > 0 {
+			return false
+		}
+		delete(mountingClusterUses, clusterID)
+		return true
+	}
+}
+
+// terminateMountingCluster releases the cluster and terminates it, if it was created
+// for mounting and no other mount operation of this process is still running on it
+func terminateMountingCluster(ctx context.Context, m interface{}, clusterID string,
+	release func() bool) error {
+	if !TerminateMountingClusterAfterUse {
+		return nil
+	}
+	if !release() {
+		log.Printf("[DEBUG] Not terminating %s, as other mounts still use it", clusterID)
+		return nil
+	}
+	clustersAPI := compute.NewClustersAPI(ctx, m)
+	clusterInfo, err := clustersAPI.Get(clusterID)
+	if err != nil {
+		return err
+	}
+	if !isMountingCluster(clusterInfo) {
+		log.Printf("[DEBUG] Not terminating %s, as it was not created for mounting", clusterID)
+		return nil
+	}
+	log.Printf("[INFO] Terminating mounting cluster %s", clusterID)
+	return clustersAPI.Terminate(clusterID)
+}
+
+// mountingClusterNodeType returns the smallest node type with local disk,
+// using the same lookup as `databricks_node_type` data source
+func mountingClusterNodeType(clustersAPI compute.ClustersAPI) string {
+	return clustersAPI.GetSmallestNodeType(compute.NodeTypeRequest{
+		LocalDisk: true,
+	})
+}
+
+// mergeClusterTags merges custom tags into a new map, where tags from the latter maps
+// take precedence, so that tags required for mounting are never overridden by defaults
+func mergeClusterTags(tags ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, t := range tags {
+		for k, v := range t {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// singleNodeMountingCluster returns specification of the smallest autoterminating cluster,
+// tagged with default tags from provider configuration
+func singleNodeMountingCluster(clustersAPI compute.ClustersAPI, name string,
+	defaultTags map[string]string) compute.Cluster {
+	return compute.Cluster{
+		NumWorkers:  0,
+		ClusterName: name,
+		SparkVersion: clustersAPI.LatestSparkVersionOrDefault(
+			compute.SparkVersionRequest{
+				Latest:          true,
+				LongTermSupport: true,
+			}),
+		NodeTypeID:             mountingClusterNodeType(clustersAPI),
+		AutoterminationMinutes: 10,
+		SparkConf: map[string]string{
+			"spark.master":                     "local[*]",
+			"spark.databricks.cluster.profile": "singleNode",
+		},
+		CustomTags: mergeClusterTags(defaultTags, map[string]string{
+			"ResourceClass": "SingleNode",
+		}),
+	}
+}
+
 func getMountingClusterID(ctx context.Context, client *common.DatabricksClient, clusterID string) (string, error) {
 	clustersAPI := compute.NewClustersAPI(ctx, client)
 	if clusterID == "" {
-		r := compute.Cluster{
-			NumWorkers:  0,
-			ClusterName: "terraform-mount",
-			SparkVersion: clustersAPI.LatestSparkVersionOrDefault(
-				compute.SparkVersionRequest{
-					Latest:          true,
-					LongTermSupport: true,
-				}),
-			NodeTypeID: clustersAPI.GetSmallestNodeType(
-				compute.NodeTypeRequest{
-					LocalDisk: true,
-				}),
-
-			AutoterminationMinutes: 10,
-			SparkConf: map[string]string{
-				"spark.master":                     "local[*]",
-				"spark.databricks.cluster.profile": "singleNode",
-			},
-			CustomTags: map[string]string{
-				"ResourceClass": "SingleNode",
-			},
-		}
+		r := singleNodeMountingCluster(clustersAPI, "terraform-mount", client.DefaultClusterTags)
 		cluster, err := clustersAPI.GetOrCreateRunningCluster("terraform-mount", r)
 		if err != nil {
 			return "", err
 		}
 		return cluster.ClusterID, nil
 	}
+	// mount state is kept when the cluster is unavailable, as it says nothing
+	// about presence of the mount itself
 	clusterInfo, err := clustersAPI.Get(clusterID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("Mounting cluster %s is unavailable: %w", clusterID, err)
 	}
 	if !clusterInfo.IsRunningOrResizing() {
-		err = clustersAPI.Start(clusterInfo.ClusterID)
+		err = clustersAPI.Start(clusterID)
+		var unavailable compute.ClusterUnavailableError
+		if errors.As(err, &unavailable) && unavailable.State == compute.ClusterStateTerminated {
+			// cluster may get terminated while starting, so it's worth another try.
+			// clusters in ERROR state need manual intervention.
+			log.Printf("[WARN] Mounting cluster %s got terminated while starting, retrying: %s",
+				clusterID, unavailable.StateMessage)
+			err = clustersAPI.Start(clusterID)
+		}
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("Mounting cluster %s cannot be started: %w", clusterID, err)
 		}
 	}
 	return clusterID, nil
 }
 
+// mountFromData reads mount template of tpl type from resource data
+func mountFromData(tpl interface{}, d *schema.ResourceData, r *schema.Resource) (Mount, error) {
+	mountType := reflect.TypeOf(tpl)
+	mountTypePointer := reflect.New(mountType)
+	mountReflectValue := mountTypePointer.Elem()
+	err := common.DataToReflectValue(d, r, mountReflectValue)
+	if err != nil {
+		return nil, err
+	}
+	return mountReflectValue.Interface().(Mount), nil
+}
+
 func mountCluster(ctx context.Context, tpl interface{}, d *schema.ResourceData,
 	m interface{}, r *schema.Resource) (Mount, MountPoint, error) {
 	var mountPoint MountPoint
@@ -165,17 +688,19 @@ func mountCluster(ctx context.Context, tpl interface{}, d *schema.ResourceData,
 	}
 	mountPoint.clusterID = clusterID
 
-	mountType := reflect.TypeOf(tpl)
-	mountTypePointer := reflect.New(mountType)
-	mountReflectValue := mountTypePointer.Elem()
-	err = common.DataToReflectValue(d, r, mountReflectValue)
+	mountConfig, err = mountFromData(tpl, d, r)
 	if err != nil {
 		return mountConfig, mountPoint, err
 	}
-	mountInterface := mountReflectValue.Interface()
-	mountConfig = mountInterface.(Mount)
 
 	name := d.Get("mount_name").(string)
+	if name == "" {
+		// imported mounts have only the ID known
+		name = d.Id()
+		if err = d.Set("mount_name", name); err != nil {
+			return mountConfig, mountPoint, err
+		}
+	}
 	mountPoint.name = name
 	d.SetId(name)
 
@@ -185,24 +710,126 @@ func mountCluster(ctx context.Context, tpl interface{}, d *schema.ResourceData,
 // returns resource create mount for object store on workspace
 func mountCreate(tpl interface{}, r *schema.Resource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := checkMountsEnabled(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := validateMountSecrets(ctx, tpl, d, m, r); err != nil {
+			return diag.FromErr(err)
+		}
 		mountConfig, mountPoint, err := mountCluster(ctx, tpl, d, m, r)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		release := useMountingCluster(mountPoint.clusterID)
+		defer release()
+		if err = validateMountRuntime(ctx, m, mountPoint.clusterID, mountConfig); err != nil {
+			return diag.FromErr(err)
+		}
 		log.Printf("[INFO] Mounting %s at /mnt/%s", mountConfig.Source(), d.Id())
 		source, err := mountPoint.Mount(mountConfig)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		err = d.Set("source", source)
+		err = d.Set("source", normalizeMountSource(source))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		diags := readMountSource(ctx, mountPoint, d)
+		if diags.HasError() {
+			return diags
+		}
+		if err = terminateMountingCluster(ctx, m, mountPoint.clusterID, release); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
+}
+
+// returns resource update function, that changes configuration of the mount in place
+func mountUpdate(tpl interface{}, r *schema.Resource) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := validateMountSecrets(ctx, tpl, d, m, r); err != nil {
+			return diag.FromErr(err)
+		}
+		if !d.HasChangesExcept("verify", "skip_read_verification", "validate_secrets",
+			"check_workspace_conf") {
+			return mountRead(tpl, r)(ctx, d, m)
+		}
+		mountConfig, mountPoint, err := mountCluster(ctx, tpl, d, m, r)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		release := useMountingCluster(mountPoint.clusterID)
+		defer release()
+		if err = validateMountRuntime(ctx, m, mountPoint.clusterID, mountConfig); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] Updating configuration of %s mounted at /mnt/%s", mountConfig.Source(), d.Id())
+		if _, err = mountPoint.UpdateMount(mountConfig); err != nil {
+			return diag.FromErr(err)
+		}
+		diags := readMountSource(ctx, mountPoint, d)
+		if diags.HasError() {
+			return diags
+		}
+		if err = terminateMountingCluster(ctx, m, mountPoint.clusterID, release); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
+}
+
+// returns resource update function, that unmounts and mounts again through the
+// current mounting cluster, so that the mount picks up its credentials
+func mountRemount(tpl interface{}, r *schema.Resource) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		mountConfig, mountPoint, err := mountCluster(ctx, tpl, d, m, r)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		return readMountSource(ctx, mountPoint, d)
+		release := useMountingCluster(mountPoint.clusterID)
+		defer release()
+		log.Printf("[INFO] Remounting %s at /mnt/%s through %s",
+			mountConfig.Source(), d.Id(), mountPoint.clusterID)
+		if err = mountPoint.Delete(); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err = mountPoint.Mount(mountConfig); err != nil {
+			return diag.FromErr(err)
+		}
+		diags := readMountSource(ctx, mountPoint, d)
+		if diags.HasError() {
+			return diags
+		}
+		if err = terminateMountingCluster(ctx, m, mountPoint.clusterID, release); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
 	}
 }
 
-// reads and sets source of the mount
+// normalizeMountSource canonicalizes mount source URI by lowercasing the scheme
+// and stripping trailing slashes, as dbutils.fs.mounts() may return either form
+func normalizeMountSource(source string) string {
+	parts := strings.SplitN(source, "://", 2)
+	if len(parts) != 2 {
+		return strings.TrimRight(source, "/")
+	}
+	return strings.ToLower(parts[0]) + "://" + strings.TrimRight(parts[1], "/")
+}
+
+// mountURL returns object store URL of the normalized mount source, where s3a
+// and s3n buckets are given with the s3 scheme, that other tools understand
+func mountURL(source string) string {
+	for _, scheme := range []string{"s3a://", "s3n://"} {
+		if strings.HasPrefix(source, scheme) {
+			return "s3://" + strings.TrimPrefix(source, scheme)
+		}
+	}
+	return source
+}
+
+// readMountSource reads and sets source, mount point and URL of the mount
 func readMountSource(ctx context.Context, mp MountPoint, d *schema.ResourceData) diag.Diagnostics {
 	source, err := mp.Source()
 	if err != nil {
@@ -213,23 +840,76 @@ func readMountSource(ctx context.Context, mp MountPoint, d *schema.ResourceData)
 		}
 		return diag.FromErr(err)
 	}
+	source = normalizeMountSource(source)
 	if err = d.Set("source", source); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = d.Set("mount_point", "/mnt/"+d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("url", mountURL(source)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// skipMountRead is true, if the mount trusts its state instead of listing mounts on
+// the cluster. Imported mounts and mounts with verification enabled are always read.
+func skipMountRead(d *schema.ResourceData) bool {
+	return d.Get("skip_read_verification").(bool) && !d.Get("verify").(bool) &&
+		d.Get("source").(string) != ""
+}
+
+// readMountFromState sets paths of the mount from the source in state, without
+// running any commands, so that drift of the mount is not detected
+func readMountFromState(d *schema.ResourceData) diag.Diagnostics {
+	log.Printf("[DEBUG] Skipping read of /mnt/%s, as skip_read_verification is enabled", d.Id())
+	if err := d.Set("mount_point", "/mnt/"+d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("url", mountURL(d.Get("source").(string))); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 // return resource reader function
-func mountRead(tpl Mount, r *schema.Resource) schema.ReadContextFunc {
+func mountRead(tpl interface{}, r *schema.Resource) schema.ReadContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if skipMountRead(d) {
+			return readMountFromState(d)
+		}
 		_, mp, err := mountCluster(ctx, tpl, d, m, r)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		return readMountSource(ctx, mp, d)
+		release := useMountingCluster(mp.clusterID)
+		defer release()
+		diags := readMountSource(ctx, mp, d)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		if err = readMountHealth(mp, d); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
 	}
 }
 
+// readMountHealth lists the mount, if verification is enabled. Failed listing
+// doesn't fail the read, so that broken mounts could still be fixed or removed.
+func readMountHealth(mp MountPoint, d *schema.ResourceData) error {
+	if !d.Get("verify").(bool) {
+		return nil
+	}
+	healthy := true
+	if err := mp.Verify(); err != nil {
+		log.Printf("[WARN] /mnt/%s is mounted, but cannot be listed: %s", d.Id(), err)
+		healthy = false
+	}
+	return d.Set("healthy", healthy)
+}
+
 // returns delete resource function
 func mountDelete(tpl Mount, r *schema.Resource) schema.DeleteContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -237,10 +917,15 @@ func mountDelete(tpl Mount, r *schema.Resource) schema.DeleteContextFunc {
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		release := useMountingCluster(mp.clusterID)
+		defer release()
 		log.Printf("[INFO] Unmounting /mnt/%s", d.Id())
 		if err = mp.Delete(); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = terminateMountingCluster(ctx, m, mp.clusterID, release); err != nil {
+			return diag.FromErr(err)
+		}
 		return nil
 	}
 }
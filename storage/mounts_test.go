@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -14,6 +16,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/stretchr/testify/assert"
@@ -117,6 +120,17 @@ func TestValidateMountDirectory(t *testing.T) {
 
 const expectedCommandResp = "done"
 
+// testMountListing renders output of the mounts listing command with a single mount
+func testMountListing(name, source string) string {
+	listing, _ := json.Marshal([]MountInfo{
+		{
+			MountPoint: "/mnt/" + name,
+			Source:     source,
+		},
+	})
+	return string(listing)
+}
+
 func testMountFuncHelper(t *testing.T, mountFunc func(mp MountPoint, mount Mount) (string, error), mount Mount,
 	mountName, expectedCommand string) {
 	c := common.DatabricksClient{
@@ -131,7 +145,7 @@ func testMountFuncHelper(t *testing.T, mountFunc func(mp MountPoint, mount Mount
 	c.WithCommandMock(func(commandStr string) (s string, e error) {
 		called = true
 		assert.Equal(t, internal.TrimLeadingWhitespace(expectedCommand), internal.TrimLeadingWhitespace(commandStr))
-		return expectedCommandResp, nil
+		return testMountListing(mountName, expectedCommandResp), nil
 	})
 
 	ctx := context.Background()
@@ -149,8 +163,8 @@ func testMountFuncHelper(t *testing.T, mountFunc func(mp MountPoint, mount Mount
 
 type mockMount struct{}
 
-func (t mockMount) Source() string            { return "fake-mount" }
-func (t mockMount) Config() map[string]string { return map[string]string{"fake-key": "fake-value"} }
+func (t mockMount) Source() string      { return "fake-mount" }
+func (t mockMount) Config() MountConfig { return MountConfig{"fake-key": "fake-value"} }
 
 func TestMountPoint_Mount(t *testing.T) {
 	mount := mockMount{}
@@ -158,7 +172,12 @@ func TestMountPoint_Mount(t *testing.T) {
 	expectedMountConfig := `{"fake-key":"fake-value"}`
 	mountName := "this_mount"
 	expectedCommand := fmt.Sprintf(`
-		def safe_mount(mount_point, mount_source, configs):
+		def safe_mount(mount_point, mount_source, configs, update):
+			if update:
+				dbutils.fs.updateMount(mount_source, mount_point, extra_configs=configs)
+				dbutils.fs.refreshMounts()
+				dbutils.fs.ls(mount_point)
+				return mount_source
 			for mount in dbutils.fs.mounts():
 				if mount.mountPoint == mount_point and mount.source == mount_source:
 					return
@@ -173,28 +192,120 @@ func TestMountPoint_Mount(t *testing.T) {
 				except Exception as e2:
 					print("Failed to unmount", e2)
 				raise e
-		mount_source = safe_mount("/mnt/%s", %q, %s)
-		dbutils.notebook.exit(mount_source)
+		safe_mount("/mnt/%[1]s", %[2]q, %[3]s, False)
+		import json
+		dbutils.fs.refreshMounts()
+		dbutils.notebook.exit(json.dumps([{
+			"mount_point": mount.mountPoint,
+			"source": mount.source,
+			"encryption_type": mount.encryptionType,
+		} for mount in dbutils.fs.mounts() if mount.mountPoint == "/mnt/%[1]s"]))
 	`, mountName, expectedMountSource, expectedMountConfig)
 	testMountFuncHelper(t, func(mp MountPoint, mount Mount) (s string, e error) {
 		return mp.Mount(mount)
 	}, mount, mountName, expectedCommand)
 }
 
+func TestMountPoint_Mount_FailedWithOutput(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandResultsMock(func(commandStr string) common.CommandResults {
+		// text output of the command does not mean it has succeeded
+		return common.CommandResults{
+			ResultType: "error",
+			Summary:    "Exception: Operation failed: Forbidden",
+			Data:       "fake-mount",
+		}
+	})
+	mp := NewMountPoint(c.CommandExecutor(context.Background()), "this_mount", "abc")
+	source, err := mp.Mount(mockMount{})
+	assert.EqualError(t, err, "Operation failed: Forbidden")
+	assert.Equal(t, "", source)
+}
+
+func TestMountPoint_EscapesName(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	var commands []string
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		commands = append(commands, commandStr)
+		return testMountListing("a\")\nimport os", "s3a://a"), nil
+	})
+	mp := MountPoint{
+		exec:      c.CommandExecutor(context.Background()),
+		clusterID: "random_cluster_id",
+		name:      "a\")\nimport os",
+	}
+	_, err = mp.Source()
+	require.NoError(t, err)
+	err = mp.Delete()
+	require.NoError(t, err)
+	require.Len(t, commands, 2)
+	for _, command := range commands {
+		assert.Contains(t, command, `"/mnt/a\")\nimport os"`)
+		assert.NotContains(t, command, "\nimport os")
+	}
+}
+
+func TestValidateMountName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"a":       true,
+		"a/b_c-1": true,
+		"a/b/c":   true,
+		`a"b`:     false,
+		"a\nb":    false,
+		"a/../b":  false,
+		"/a":      false,
+		"a/":      false,
+		"a//b":    false,
+		"":        false,
+		"a'b":     false,
+		"a\\b":    false,
+	} {
+		_, errs := validateMountName(name, "mount_name")
+		assert.Equal(t, valid, len(errs) == 0, name)
+	}
+}
+
 func TestMountPoint_Source(t *testing.T) {
 	mountName := "this_mount"
 	expectedCommand := fmt.Sprintf(`
+		import json
 		dbutils.fs.refreshMounts()
-		for mount in dbutils.fs.mounts():
-			if mount.mountPoint == "/mnt/%s":
-				dbutils.notebook.exit(mount.source)
-		raise Exception("Mount not found")
+		dbutils.notebook.exit(json.dumps([{
+			"mount_point": mount.mountPoint,
+			"source": mount.source,
+			"encryption_type": mount.encryptionType,
+		} for mount in dbutils.fs.mounts() if mount.mountPoint == "/mnt/%s"]))
 	`, mountName)
 	testMountFuncHelper(t, func(mp MountPoint, mount Mount) (s string, e error) {
 		return mp.Source()
 	}, nil, mountName, expectedCommand)
 }
 
+func TestMountPoint_Source_ExactMountPoint(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandMock(func(commandStr string) (string, error) {
+		return `[{"mount_point": "/mnt/this_mount/nested", "source": "s3a://other/mnt/this_mount"}]`, nil
+	})
+	mp := NewMountPoint(c.CommandExecutor(context.Background()), "this_mount", "abc")
+	_, err = mp.Source()
+	assert.EqualError(t, err, "Mount not found")
+}
+
 func TestMountPoint_Delete(t *testing.T) {
 	mountName := "this_mount"
 	expectedCommand := fmt.Sprintf(`
@@ -210,3 +321,451 @@ func TestMountPoint_Delete(t *testing.T) {
 		return expectedCommandResp, mp.Delete()
 	}, nil, mountName, expectedCommand)
 }
+
+func TestNormalizeMountSource(t *testing.T) {
+	for source, expected := range map[string]string{
+		"s3a://bucket":  "s3a://bucket",
+		"s3a://bucket/": "s3a://bucket",
+		"S3A://Bucket/": "s3a://Bucket",
+		"abfss://container@account.dfs.core.windows.net/": "abfss://container@account.dfs.core.windows.net",
+		"gs://data/dir//": "gs://data/dir",
+		"/local/":         "/local",
+	} {
+		assert.Equal(t, expected, normalizeMountSource(source), source)
+	}
+}
+
+func TestMountURL(t *testing.T) {
+	for source, expected := range map[string]string{
+		"s3a://bucket": "s3://bucket",
+		"s3n://bucket": "s3://bucket",
+		"s3://bucket":  "s3://bucket",
+		"gs://data":    "gs://data",
+		"abfss://container@account.dfs.core.windows.net": "abfss://container@account.dfs.core.windows.net",
+	} {
+		assert.Equal(t, expected, mountURL(source), source)
+	}
+}
+
+func TestMountConfig_String(t *testing.T) {
+	testCases := []struct {
+		config   MountConfig
+		expected string
+	}{
+		{nil, `{}`},
+		{MountConfig{}, `{}`},
+		{MountConfig{"b": "2", "a": "1"}, `{"a":"1","b":"2"}`},
+		{MountConfig{"a": `it's`}, `{"a":"it's"}`},
+		{MountConfig{"a": `say "hi"`}, `{"a":"say \"hi\""}`},
+		{MountConfig{"a": `C:\dir\`}, `{"a":"C:\\dir\\"}`},
+		{MountConfig{"a": "line\nbreak"}, `{"a":"line\nbreak"}`},
+		{MountConfig{"a": "{secrets/scope/key}"}, `{"a":dbutils.secrets.get("scope", "key")}`},
+		{MountConfig{"a": `{secrets/sc"ope/k\ey}`}, `{"a":dbutils.secrets.get("sc\"ope", "k\\ey")}`},
+		{MountConfig{"a": "prefix {secrets/scope/key}"}, `{"a":"prefix {secrets/scope/key}"}`},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.config.String())
+	}
+}
+
+func TestMountConfig_SecretReferences(t *testing.T) {
+	assert.Equal(t, [][2]string{{"a", "b"}, {"c", "d"}}, MountConfig{
+		"z": "{secrets/c/d}",
+		"y": "plain value",
+		"x": "{secrets/a/b}",
+	}.secretReferences())
+	assert.Nil(t, MountConfig{"a": "prefix {secrets/scope/key}"}.secretReferences())
+}
+
+func adlsGen2MountSecretsFixture(fixtures []qa.HTTPFixture) qa.ResourceFixture {
+	return qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceAzureAdlsGen2Mount(),
+		State: map[string]interface{}{
+			"cluster_id":             "this_cluster",
+			"container_name":         "e",
+			"mount_name":             "this_mount",
+			"storage_account_name":   "test-adls-gen2",
+			"tenant_id":              "a",
+			"client_id":              "b",
+			"client_secret_scope":    "c",
+			"client_secret_key":      "d",
+			"initialize_file_system": true,
+			"validate_secrets":       true,
+		},
+		Create: true,
+	}
+}
+
+func TestValidateMountSecrets_MissingScope(t *testing.T) {
+	adlsGen2MountSecretsFixture([]qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/list?scope=c",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Scope c does not exist!",
+			},
+		},
+	}).ExpectError(t, "Secret scope c does not exist")
+}
+
+func TestValidateMountSecrets_MissingKey(t *testing.T) {
+	adlsGen2MountSecretsFixture([]qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/list?scope=c",
+			Response: access.SecretsList{
+				Secrets: []access.SecretMetadata{
+					{
+						Key: "dd",
+					},
+				},
+			},
+		},
+	}).ExpectError(t, "Secret d does not exist in c scope")
+}
+
+func TestValidateMountSecrets_Disabled(t *testing.T) {
+	r := ResourceAzureAdlsGen2Mount()
+	err := validateMountSecrets(context.Background(), AzureADLSGen2Mount{},
+		r.TestResourceData(), nil, r)
+	assert.NoError(t, err)
+}
+
+func TestValidateMountSecrets_OnPlan(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/list?scope=c",
+			Response: access.SecretsList{
+				Secrets: []access.SecretMetadata{
+					{
+						Key: "dd",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	r := ResourceAzureAdlsGen2Mount()
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"container_name":       "e",
+		"mount_name":           "this_mount",
+		"storage_account_name": "test-adls-gen2",
+		"tenant_id":            "a",
+		"client_id":            "b",
+		"client_secret_scope":  "c",
+		"client_secret_key":    "d",
+		"validate_secrets":     true,
+	}), client)
+	assert.EqualError(t, err, "Secret d does not exist in c scope")
+}
+
+func TestAddMountSecretValidationField_OnlyWithSecrets(t *testing.T) {
+	assert.Contains(t, ResourceAzureAdlsGen2Mount().Schema, "validate_secrets")
+	assert.NotContains(t, ResourceAWSS3Mount().Schema, "validate_secrets")
+}
+
+func TestIsMountingCluster(t *testing.T) {
+	assert.True(t, isMountingCluster(compute.ClusterInfo{
+		ClusterName: "terraform-mount",
+		CustomTags:  map[string]string{"ResourceClass": "SingleNode"},
+	}))
+	assert.True(t, isMountingCluster(compute.ClusterInfo{
+		ClusterName: "terraform-mount-s3-access",
+		CustomTags: map[string]string{
+			MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/s3-access",
+		},
+	}))
+	assert.False(t, isMountingCluster(compute.ClusterInfo{
+		ClusterName: "terraform-mount",
+	}), "user cluster with the same name")
+	assert.False(t, isMountingCluster(compute.ClusterInfo{
+		ClusterName: "Shared Autoscaling",
+		CustomTags:  map[string]string{"ResourceClass": "SingleNode"},
+	}))
+}
+
+func TestGetMountingClusterID_ClusterGoneIsWrapped(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=b",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "NOT_FOUND",
+				Message:   "Cluster b does not exist",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	_, err = getMountingClusterID(context.Background(), client, "b")
+	qa.AssertErrorStartsWith(t, err, "Mounting cluster b is unavailable: Cluster b does not exist")
+	var apiErr common.APIError
+	require.True(t, errors.As(err, &apiErr), err)
+	assert.True(t, apiErr.IsMissing())
+}
+
+func TestGetMountingClusterID_ClusterInErrorState(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/clusters/get?cluster_id=b",
+			ReuseRequest: true,
+			Response: compute.ClusterInfo{
+				ClusterID:    "b",
+				State:        compute.ClusterStateError,
+				StateMessage: "Driver is unresponsive",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/start",
+			ExpectedRequest: compute.ClusterID{
+				ClusterID: "b",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	_, err = getMountingClusterID(context.Background(), client, "b")
+	require.Error(t, err)
+	var unavailable compute.ClusterUnavailableError
+	require.True(t, errors.As(err, &unavailable), err)
+	assert.Equal(t, compute.ClusterState(compute.ClusterStateError), unavailable.State)
+	assert.Equal(t, "Driver is unresponsive", unavailable.StateMessage)
+	qa.AssertErrorStartsWith(t, err, "Mounting cluster b cannot be started: "+
+		"b is not able to transition from ERROR to RUNNING: Driver is unresponsive.")
+}
+
+func TestGetMountingClusterID_ClusterTerminatedWhileStarting(t *testing.T) {
+	terminated := compute.ClusterInfo{
+		ClusterID:    "b",
+		State:        compute.ClusterStateTerminated,
+		StateMessage: "Instances were lost",
+	}
+	start := qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/2.0/clusters/start",
+		ExpectedRequest: compute.ClusterID{
+			ClusterID: "b",
+		},
+	}
+	getTerminated := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=b",
+		Response: terminated,
+	}
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		getTerminated,
+		getTerminated,
+		start,
+		getTerminated,
+		getTerminated,
+		start,
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=b",
+			Response: compute.ClusterInfo{
+				ClusterID: "b",
+				State:     compute.ClusterStateRunning,
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	clusterID, err := getMountingClusterID(context.Background(), client, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", clusterID)
+}
+
+func TestMountCreate_Timeout(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/clusters/get?cluster_id=b",
+			ReuseRequest: true,
+			Response: compute.ClusterInfo{
+				ClusterID: "b",
+				State:     compute.ClusterStatePending,
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+	client.WithCommandMock(func(commandStr string) (string, error) {
+		assert.Fail(t, "Mount command should not run on pending cluster")
+		return "", nil
+	})
+
+	r := ResourceAzureAdlsGen2Mount()
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":             "b",
+		"container_name":         "e",
+		"mount_name":             "this_mount",
+		"storage_account_name":   "test-adls-gen2",
+		"tenant_id":              "a",
+		"client_id":              "b",
+		"client_secret_scope":    "c",
+		"client_secret_key":      "d",
+		"initialize_file_system": true,
+		"timeouts": []interface{}{
+			map[string]interface{}{
+				"create": "1s",
+			},
+		},
+	}), client)
+	require.NoError(t, err)
+
+	_, diags := r.Apply(ctx, nil, diff, client)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Mounting cluster b cannot be started: context deadline exceeded: "+
+		"b is PENDING, but has to be RUNNING", diags[0].Summary)
+}
+
+func TestSingleNodeMountingCluster_LatestLTSRuntime(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: compute.SparkVersionsList{
+				SparkVersions: []compute.SparkVersion{
+					{
+						Version:     "7.3.x-scala2.12",
+						Description: "7.3 LTS (includes Apache Spark 3.0.1, Scala 2.12)",
+					},
+					{
+						Version:     "7.6.x-scala2.12",
+						Description: "7.6 (includes Apache Spark 3.0.1, Scala 2.12)",
+					},
+					{
+						Version:     "7.3.x-gpu-ml-scala2.12",
+						Description: "7.3 LTS ML (includes Apache Spark 3.0.1, GPU, Scala 2.12)",
+					},
+					{
+						Version:     "8.3.x-scala2.12",
+						Description: "8.3 LTS (includes Apache Spark 3.1.1, Scala 2.12)",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list-node-types",
+			Response: compute.NodeTypeList{},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		cluster := singleNodeMountingCluster(compute.NewClustersAPI(ctx, client),
+			"terraform-mount", nil)
+		assert.Equal(t, "8.3.x-scala2.12", cluster.SparkVersion,
+			"runtime has to be looked up like in databricks_spark_version")
+	})
+}
+
+func TestValidateMountRuntime(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=new",
+			Response: compute.ClusterInfo{
+				SparkVersion: "7.3.x-scala2.12",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=custom",
+			Response: compute.ClusterInfo{
+				SparkVersion: "custom:my-image",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		adls := AzureADLSGen2Mount{ContainerName: "c", StorageAccountName: "a"}
+		assert.NoError(t, validateMountRuntime(ctx, client, "new", adls))
+		assert.NoError(t, validateMountRuntime(ctx, client, "custom", adls))
+		// no requests are made for mounts without minimum runtime
+		assert.NoError(t, validateMountRuntime(ctx, client, "unknown", AWSIamMount{S3BucketName: "b"}))
+	})
+}
+
+func TestMountPoint_Source_UnknownResultType(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	require.NoError(t, err)
+	c.WithCommandResultsMock(func(commandStr string) common.CommandResults {
+		return common.CommandResults{
+			ResultType: "images",
+			Data:       "irrelevant",
+		}
+	})
+	mp := NewMountPoint(c.CommandExecutor(context.Background()), "this_mount", "abc")
+	_, err = mp.Source()
+	assert.EqualError(t, err, "Unknown result type images: irrelevant")
+}
+
+func TestTerminateMountingCluster_OtherMountsInFlight(t *testing.T) {
+	TerminateMountingClusterAfterUse = true
+	defer func() {
+		TerminateMountingClusterAfterUse = false
+	}()
+	mountingCluster := compute.ClusterInfo{
+		ClusterID:   "abc",
+		ClusterName: "terraform-mount-s3-access",
+		State:       compute.ClusterStateRunning,
+		CustomTags: map[string]string{
+			MountingClusterInstanceProfileTag: "arn:aws:iam::1234567:instance-profile/s3-access",
+		},
+	}
+	first := useMountingCluster("abc")
+	second := useMountingCluster("abc")
+	defer first()
+	defer second()
+
+	// no requests are expected, as the second mount still runs on the cluster
+	idle, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{})
+	require.NoError(t, err)
+	defer server.Close()
+	err = terminateMountingCluster(context.Background(), idle, "abc", first)
+	require.NoError(t, err)
+
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: mountingCluster,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/delete",
+			ExpectedRequest: compute.ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: compute.ClusterInfo{
+				ClusterID: "abc",
+				State:     compute.ClusterStateTerminated,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	err = terminateMountingCluster(context.Background(), client, "abc", second)
+	require.NoError(t, err)
+	assert.NotContains(t, mountingClusterUses, "abc")
+}
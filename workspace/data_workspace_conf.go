@@ -0,0 +1,46 @@
+package workspace
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceWorkspaceConf reads workspace configuration for specified keys
+func DataSourceWorkspaceConf() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			keys := []string{}
+			conf := map[string]interface{}{}
+			for _, k := range d.Get("keys").(*schema.Set).List() {
+				keys = append(keys, k.(string))
+				conf[k.(string)] = ""
+			}
+			sort.Strings(keys)
+			if err := NewWorkspaceConfAPI(ctx, m).Read(&conf); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set("conf", conf); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(strings.Join(keys, ","))
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"keys": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"conf": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
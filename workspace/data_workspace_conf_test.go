@@ -0,0 +1,34 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceWorkspaceConf(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace-conf?keys=enableDbfsFileBrowser%2CenableIpAccessLists",
+				Response: map[string]interface{}{
+					"enableDbfsFileBrowser": "false",
+					"enableIpAccessLists":   "true",
+				},
+			},
+		},
+		Resource:    DataSourceWorkspaceConf(),
+		Read:        true,
+		NonWritable: true,
+		HCL:         `keys = ["enableIpAccessLists", "enableDbfsFileBrowser"]`,
+		ID:          "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "enableDbfsFileBrowser,enableIpAccessLists", d.Id())
+	assert.Equal(t, "false", d.Get("conf.enableDbfsFileBrowser"))
+	assert.Equal(t, "true", d.Get("conf.enableIpAccessLists"))
+}
@@ -0,0 +1,54 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceDirectory manages directories in the workspace
+func ResourceDirectory() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"path": FileContentSchema(nil)["path"],
+		"object_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"delete_recursive": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			path := d.Get("path").(string)
+			// mkdirs succeeds for existing directories as well
+			if err := NewNotebooksAPI(ctx, c).Mkdirs(path); err != nil {
+				return err
+			}
+			d.SetId(path)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			objectStatus, err := NewNotebooksAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			if objectStatus.ObjectType != Directory {
+				return fmt.Errorf("%s is %s, not a directory", d.Id(), objectStatus.ObjectType)
+			}
+			if err = d.Set("path", d.Id()); err != nil {
+				return err
+			}
+			return d.Set("object_id", objectStatus.ObjectID)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewNotebooksAPI(ctx, c).Delete(d.Id(), d.Get("delete_recursive").(bool))
+		},
+	}.ToResource()
+}
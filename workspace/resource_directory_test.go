@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceDirectoryCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{
+					"path": "/Shared/mounts/etl",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fmounts%2Fetl",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Directory,
+					Path:       "/Shared/mounts/etl",
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		HCL:      `path = "/Shared/mounts/etl"`,
+		Create:   true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Shared/mounts/etl", d.Id())
+	assert.Equal(t, 4567, d.Get("object_id"))
+}
+
+func TestResourceDirectoryCreate_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_ALREADY_EXISTS",
+					Message:   "Node named 'etl' already exists",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceDirectory(),
+		HCL:      `path = "/Shared/mounts/etl"`,
+		Create:   true,
+	}.ExpectError(t, "Node named 'etl' already exists")
+}
+
+func TestResourceDirectoryRead_NotDirectory(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fetl",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/Shared/etl",
+					Language:   Python,
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Read:     true,
+		New:      true,
+		ID:       "/Shared/etl",
+	}.ExpectError(t, "/Shared/etl is NOTEBOOK, not a directory")
+}
+
+func TestResourceDirectoryRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fetl",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Path (/Shared/etl) doesn't exist.",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceDirectory(),
+		Read:     true,
+		Removed:  true,
+		ID:       "/Shared/etl",
+	}.ApplyNoError(t)
+}
+
+func TestResourceDirectoryDelete_Recursive(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/delete",
+				ExpectedRequest: NotebookDeleteRequest{
+					Path:      "/Shared/etl",
+					Recursive: true,
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		HCL: `
+		path = "/Shared/etl"
+		delete_recursive = true
+		`,
+		Delete: true,
+		ID:     "/Shared/etl",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Shared/etl", d.Id())
+}
@@ -201,6 +201,10 @@ func ResourceNotebook() *schema.Resource {
 				string(SQL),
 			}, false),
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				if d.Get("format").(string) != string(Source) {
+					// archives and exported notebooks carry their own language
+					return true
+				}
 				source := d.Get("source").(string)
 				if source == "" {
 					return false
@@ -222,9 +226,24 @@ func ResourceNotebook() *schema.Resource {
 			Optional: true,
 			Computed: true,
 		},
+		"overwrite": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"format": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  string(Source),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(Source),
+				string(DBC),
+				string(HTML),
+			}, false),
+		},
 	})
-	s["content_base64"].RequiredWith = []string{"language"}
-	return common.Resource{
+	r := common.Resource{
 		Schema:        s,
 		SchemaVersion: 1,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -232,8 +251,13 @@ func ResourceNotebook() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			notebooksAPI := NewNotebooksAPI(ctx, c)
 			path := d.Get("path").(string)
+			overwrite := d.Get("overwrite").(bool)
+			r, err := notebookImportRequest(d, path, content, overwrite)
+			if err != nil {
+				return err
+			}
+			notebooksAPI := NewNotebooksAPI(ctx, c)
 			parent := filepath.ToSlash(filepath.Dir(path))
 			if parent != "/" {
 				err = notebooksAPI.Mkdirs(parent)
@@ -242,18 +266,11 @@ func ResourceNotebook() *schema.Resource {
 					return err
 				}
 			}
-			lang := d.Get("language").(string)
-			if lang == "" {
-				// TODO: check what happens with empty source
-				lang = extMap[strings.ToLower(filepath.Ext(d.Get("source").(string)))]
-			}
-			if err = notebooksAPI.Create(ImportRequest{
-				Content:   base64.StdEncoding.EncodeToString(content),
-				Language:  lang,
-				Format:    "SOURCE",
-				Overwrite: true,
-				Path:      path,
-			}); err != nil {
+			if err = notebooksAPI.Create(r); err != nil {
+				if e, ok := err.(common.APIError); ok && !overwrite &&
+					e.ErrorCode == "RESOURCE_ALREADY_EXISTS" {
+					return fmt.Errorf("Notebook %s already exists and overwrite is disabled", path)
+				}
 				return err
 			}
 			d.SetId(path)
@@ -274,16 +291,85 @@ func ResourceNotebook() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			return notebooksAPI.Create(ImportRequest{
-				Content:   base64.StdEncoding.EncodeToString(content),
-				Language:  d.Get("language").(string),
-				Format:    "SOURCE",
-				Overwrite: true,
-				Path:      d.Id(),
-			})
+			r, err := notebookImportRequest(d, d.Id(), content, true)
+			if err != nil {
+				return err
+			}
+			if r.Format == string(DBC) {
+				// archives cannot be imported over existing notebooks, so content
+				// changes replace the resource. Other changes need no import.
+				return nil
+			}
+			return notebooksAPI.Create(r)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewNotebooksAPI(ctx, c).Delete(d.Id(), true)
 		},
 	}.ToResource()
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if err := forceNewDBCContent(d); err != nil {
+			return err
+		}
+		return validateNotebookLanguage(d)
+	}
+	return r
+}
+
+// forceNewDBCContent replaces notebooks imported from DBC archives on content changes,
+// as the import API cannot overwrite them and deleting them before the import would lose
+// the notebook, if the import fails
+func forceNewDBCContent(d *schema.ResourceDiff) error {
+	if d.Id() == "" || d.Get("format").(string) != string(DBC) {
+		return nil
+	}
+	for _, key := range []string{"content_base64", "source", "md5"} {
+		if !d.HasChange(key) {
+			continue
+		}
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNotebookLanguage fails the plan, if language of SOURCE notebook cannot be known
+// on import: inline content has no file extension to take it from.
+func validateNotebookLanguage(d *schema.ResourceDiff) error {
+	if d.Get("format").(string) != string(Source) {
+		return nil
+	}
+	if !d.NewValueKnown("language") || d.Get("language").(string) != "" {
+		return nil
+	}
+	if d.NewValueKnown("content_base64") && d.Get("content_base64").(string) == "" {
+		return nil
+	}
+	return fmt.Errorf("Language is required for %s notebook with content_base64 in SOURCE format",
+		d.Get("path"))
+}
+
+// notebookImportRequest prepares import of the notebook content in the configured format.
+// Language of the source is taken from file extension, unless it's set explicitly.
+func notebookImportRequest(d *schema.ResourceData, path string, content []byte, overwrite bool) (ImportRequest, error) {
+	r := ImportRequest{
+		Content:   base64.StdEncoding.EncodeToString(content),
+		Format:    d.Get("format").(string),
+		Overwrite: overwrite,
+		Path:      path,
+	}
+	switch r.Format {
+	case string(Source):
+		r.Language = d.Get("language").(string)
+		if r.Language == "" {
+			r.Language = extMap[strings.ToLower(filepath.Ext(d.Get("source").(string)))]
+		}
+		if r.Language == "" {
+			return r, fmt.Errorf("Language is required for %s notebook in SOURCE format", path)
+		}
+	case string(DBC):
+		// import API rejects overwrite flag for DBC archives
+		r.Overwrite = false
+	}
+	return r, nil
 }
@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -189,6 +191,77 @@ func TestResourceNotebookCreateSource(t *testing.T) {
 	assert.Equal(t, "/Dashboard", d.Id())
 }
 
+func TestResourceNotebookCreate_Overwrite(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content:   "YWJjCg==",
+					Path:      "/existing",
+					Language:  "PYTHON",
+					Overwrite: true,
+					Format:    "SOURCE",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Fexisting",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: "NOTEBOOK",
+					Path:       "/existing",
+					Language:   "PYTHON",
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"language":       "PYTHON",
+			"path":           "/existing",
+			"overwrite":      true,
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/existing", d.Id())
+	assert.Equal(t, true, d.Get("overwrite"))
+}
+
+func TestResourceNotebookCreate_OverwriteDisabled(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content:  "YWJjCg==",
+					Path:     "/existing",
+					Language: "PYTHON",
+					Format:   "SOURCE",
+				},
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_ALREADY_EXISTS",
+					Message:   "Path (/existing) already exists.",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceNotebook(),
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"language":       "PYTHON",
+			"path":           "/existing",
+			"overwrite":      false,
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Notebook /existing already exists and overwrite is disabled")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
 func TestResourceNotebookCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -271,3 +344,131 @@ func TestResourceNotebookUpdate(t *testing.T) {
 	}.Apply(t)
 	require.NoError(t, err)
 }
+
+func TestResourceNotebookCreate_DBC(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{
+					"path": "/Shared",
+				},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content: "YWJjCg==",
+					Path:    "/Shared/etl",
+					Format:  "DBC",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fetl",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/Shared/etl",
+					Language:   Python,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		HCL: `
+		content_base64 = "YWJjCg=="
+		path = "/Shared/etl"
+		format = "DBC"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Shared/etl", d.Id())
+	assert.Equal(t, "DBC", d.Get("format"))
+}
+
+func TestResourceNotebookCreate_NoLanguage(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNotebook(),
+		HCL: `
+		content_base64 = "YWJjCg=="
+		path = "/Shared/etl"
+		`,
+		Create: true,
+	}.ExpectError(t, "Language is required for /Shared/etl notebook with content_base64 in SOURCE format")
+}
+
+func TestResourceNotebookDiff_DBCContentRequiresNew(t *testing.T) {
+	diff, err := ResourceNotebook().Diff(context.Background(), &terraform.InstanceState{
+		ID: "/Shared/etl",
+		Attributes: map[string]string{
+			"content_base64": "YWJjCg==",
+			"md5":            "0bee89b07a248e27c83fc3d5951213c1",
+			"path":           "/Shared/etl",
+			"format":         "DBC",
+			"overwrite":      "true",
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"content_base64": "ZGVmCg==",
+		"path":           "/Shared/etl",
+		"format":         "DBC",
+	}), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew(), diff)
+}
+
+func TestResourceNotebookDiff_SourceContentUpdatesInPlace(t *testing.T) {
+	diff, err := ResourceNotebook().Diff(context.Background(), &terraform.InstanceState{
+		ID: "/Shared/etl",
+		Attributes: map[string]string{
+			"content_base64": "YWJjCg==",
+			"md5":            "0bee89b07a248e27c83fc3d5951213c1",
+			"path":           "/Shared/etl",
+			"format":         "SOURCE",
+			"language":       "PYTHON",
+			"overwrite":      "true",
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"content_base64": "ZGVmCg==",
+		"path":           "/Shared/etl",
+		"language":       "PYTHON",
+	}), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew(), diff)
+}
+
+func TestResourceNotebookUpdate_DBCWithoutImport(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fetl",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/Shared/etl",
+					Language:   Python,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		InstanceState: map[string]string{
+			"content_base64": "YWJjCg==",
+			"path":           "/Shared/etl",
+			"format":         "DBC",
+			"overwrite":      "true",
+		},
+		HCL: `
+		content_base64 = "YWJjCg=="
+		path = "/Shared/etl"
+		format = "DBC"
+		overwrite = false
+		`,
+		ID:     "/Shared/etl",
+		Update: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}